@@ -61,8 +61,11 @@ module TurbineRb
     private
 
     def init_core_server
-      # TODO: figure out what the deal is with :this_channel_is_insecure
-      core_server = TurbineCore::TurbineService::Stub.new(ENV["TURBINE_CORE_SERVER"], :this_channel_is_insecure)
+      core_server = TurbineCore::TurbineService::Stub.new(
+        core_server_target,
+        core_server_credentials,
+        channel_args: core_server_channel_args
+      )
       git_sha = ARGV[0]
 
       req = TurbineCore::InitRequest.new(
@@ -76,6 +79,32 @@ module TurbineRb
       core_server.init(req)
       core_server
     end
+
+    # Accepts host:port and unix:// socket addresses, like the Go client.
+    # gRPC only accepts absolute paths after unix://, relative ones use unix:.
+    def core_server_target(addr = ENV.fetch("TURBINE_CORE_SERVER", "localhost:50500"))
+      return addr unless addr.start_with?("unix:")
+
+      path = addr.delete_prefix("unix://").delete_prefix("unix:")
+      path.start_with?("/") ? "unix://#{path}" : "unix:#{path}"
+    end
+
+    # Uses TLS when a certificate authority or client certificate is set, and
+    # mTLS when both the client certificate and key are set.
+    def core_server_credentials(env = ENV)
+      ca, cert, key = env.values_at("TURBINE_CORE_TLS_CA", "TURBINE_CORE_TLS_CERT", "TURBINE_CORE_TLS_KEY")
+      return :this_channel_is_insecure if [ca, cert, key].all? { |v| v.nil? || v.empty? }
+
+      read = ->(file) { File.read(file) unless file.nil? || file.empty? }
+      GRPC::Core::ChannelCredentials.new(read.call(ca), read.call(key), read.call(cert))
+    end
+
+    def core_server_channel_args(env = ENV)
+      server_name = env["TURBINE_CORE_TLS_SERVER_NAME"]
+      return {} if server_name.nil? || server_name.empty?
+
+      { GRPC::Core::Channel::SSL_TARGET => server_name }
+    end
   end
 
   class ProcessImpl < Io::Meroxa::Funtime::Function::Service
@@ -15,6 +15,7 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
       optional :language, :enum, 3, "turbine_core_v2.Language"
       optional :gitSHA, :string, 4
       optional :turbineVersion, :string, 5
+      optional :deterministicIDs, :bool, 6
     end
     add_message "turbine_core_v2.AddSourceRequest" do
       optional :name, :string, 1
@@ -26,6 +27,10 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
     add_message "turbine_core_v2.ReadRecordsRequest" do
       optional :sourceStream, :string, 1
     end
+    add_message "turbine_core_v2.ReadRecordsStreamRequest" do
+      optional :sourceStream, :string, 1
+      optional :batchSize, :uint32, 2
+    end
     add_message "turbine_core_v2.ReadRecordsResponse" do
       optional :streamRecords, :message, 1, "turbine_core_v2.StreamRecords"
     end
@@ -50,12 +55,26 @@ Google::Protobuf::DescriptorPool.generated_pool.build do
       optional :destinationID, :string, 1
       optional :streamRecords, :message, 2, "turbine_core_v2.StreamRecords"
     end
+    add_message "turbine_core_v2.RegisterSecretRequest" do
+      optional :name, :string, 1
+      optional :description, :string, 2
+    end
     add_message "turbine_core_v2.GetSpecRequest" do
       optional :image, :string, 1
+      optional :profile, :string, 2
     end
     add_message "turbine_core_v2.GetSpecResponse" do
       optional :spec, :bytes, 1
     end
+    add_message "turbine_core_v2.Session" do
+      optional :id, :string, 1
+      optional :appName, :string, 2
+      optional :createdAt, :message, 3, "google.protobuf.Timestamp"
+      optional :lastUsedAt, :message, 4, "google.protobuf.Timestamp"
+    end
+    add_message "turbine_core_v2.ListSessionsResponse" do
+      repeated :sessions, :message, 1, "turbine_core_v2.Session"
+    end
     add_message "turbine_core_v2.StreamRecords" do
       optional :streamName, :string, 1
       repeated :records, :message, 2, "turbine_core_v2.Record"
@@ -83,6 +102,7 @@ module TurbineCoreV2
   AddSourceRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.AddSourceRequest").msgclass
   AddSourceResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.AddSourceResponse").msgclass
   ReadRecordsRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.ReadRecordsRequest").msgclass
+  ReadRecordsStreamRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.ReadRecordsStreamRequest").msgclass
   ReadRecordsResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.ReadRecordsResponse").msgclass
   ProcessRecordsRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.ProcessRecordsRequest").msgclass
   ProcessRecordsRequest::Process = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.ProcessRecordsRequest.Process").msgclass
@@ -90,8 +110,11 @@ module TurbineCoreV2
   AddDestinationRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.AddDestinationRequest").msgclass
   AddDestinationResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.AddDestinationResponse").msgclass
   WriteRecordsRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.WriteRecordsRequest").msgclass
+  RegisterSecretRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.RegisterSecretRequest").msgclass
   GetSpecRequest = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.GetSpecRequest").msgclass
   GetSpecResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.GetSpecResponse").msgclass
+  Session = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.Session").msgclass
+  ListSessionsResponse = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.ListSessionsResponse").msgclass
   StreamRecords = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.StreamRecords").msgclass
   Record = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.Record").msgclass
   Plugin = ::Google::Protobuf::DescriptorPool.generated_pool.lookup("turbine_core_v2.Plugin").msgclass
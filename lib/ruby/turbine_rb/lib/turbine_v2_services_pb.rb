@@ -17,10 +17,13 @@ module TurbineCoreV2
       rpc :Init, ::TurbineCoreV2::InitRequest, ::Google::Protobuf::Empty
       rpc :AddSource, ::TurbineCoreV2::AddSourceRequest, ::TurbineCoreV2::AddSourceResponse
       rpc :ReadRecords, ::TurbineCoreV2::ReadRecordsRequest, ::TurbineCoreV2::ReadRecordsResponse
+      rpc :ReadRecordsStream, ::TurbineCoreV2::ReadRecordsStreamRequest, stream(::TurbineCoreV2::ReadRecordsResponse)
       rpc :ProcessRecords, ::TurbineCoreV2::ProcessRecordsRequest, ::TurbineCoreV2::ProcessRecordsResponse
       rpc :AddDestination, ::TurbineCoreV2::AddDestinationRequest, ::TurbineCoreV2::AddDestinationResponse
       rpc :WriteRecords, ::TurbineCoreV2::WriteRecordsRequest, ::Google::Protobuf::Empty
+      rpc :RegisterSecret, ::TurbineCoreV2::RegisterSecretRequest, ::Google::Protobuf::Empty
       rpc :GetSpec, ::TurbineCoreV2::GetSpecRequest, ::TurbineCoreV2::GetSpecResponse
+      rpc :ListSessions, ::Google::Protobuf::Empty, ::TurbineCoreV2::ListSessionsResponse
     end
 
     Stub = Service.rpc_stub_class
@@ -1,5 +1,7 @@
 # frozen_string_literal: true
 
+require "tmpdir"
+
 RSpec.describe TurbineRb do
   let(:my_process) { Class.new(TurbineRb::Process) }
   let(:app) { Class.new }
@@ -36,6 +38,37 @@ RSpec.describe TurbineRb do
       verify { |m| grpc_server.handle(m.is_a(TurbineRb::ProcessImpl)) }
     end
   end
+
+  describe ".core_server_target" do
+    it "keeps TCP addresses" do
+      expect(described_class.send(:core_server_target, "localhost:50500")).to eq("localhost:50500")
+    end
+
+    it "keeps absolute unix socket addresses" do
+      expect(described_class.send(:core_server_target, "unix:///tmp/core.sock")).to eq("unix:///tmp/core.sock")
+    end
+
+    it "rewrites relative unix socket addresses" do
+      expect(described_class.send(:core_server_target, "unix://core.sock")).to eq("unix:core.sock")
+    end
+  end
+
+  describe ".core_server_credentials" do
+    it "is insecure without TLS settings" do
+      expect(described_class.send(:core_server_credentials, {})).to eq(:this_channel_is_insecure)
+    end
+
+    it "uses TLS with a certificate authority" do
+      Dir.mktmpdir do |dir|
+        ca = File.join(dir, "ca.pem")
+        File.write(ca, "ca")
+        channel_credentials = Mocktail.of_next(GRPC::Core::ChannelCredentials)
+
+        credentials = described_class.send(:core_server_credentials, { "TURBINE_CORE_TLS_CA" => ca })
+        expect(credentials).to eq(channel_credentials)
+      end
+    end
+  end
 end
 
 RSpec.describe TurbineRb::ProcessImpl do
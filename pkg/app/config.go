@@ -3,6 +3,7 @@ package app
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"os"
 	"path"
@@ -14,7 +15,70 @@ import (
 type Config struct {
 	Name     string            `json:"name"`
 	Fixtures map[string]string `json:"fixtures"`
-	Language ir.Lang           `json:"language"`
+	// FixtureFormats sets the format of fixtures, which is otherwise
+	// detected from the file.
+	FixtureFormats map[string]string `json:"fixture_formats,omitempty"`
+	Functions      map[string]string `json:"functions,omitempty"`
+	// Destinations selects where the records written to each destination go
+	// during local runs. Records of unlisted destinations are printed.
+	Destinations map[string]Destination `json:"destinations,omitempty"`
+	// Profiles holds the variables of each environment profile, resolving
+	// the ${NAME} placeholders of plugin configs when a spec is built for
+	// that profile.
+	Profiles map[string]map[string]string `json:"profiles,omitempty"`
+	Language ir.Lang                      `json:"language"`
+}
+
+// Formats of fixture files.
+const (
+	// FixtureJSON is a JSON array of OpenCDC records.
+	FixtureJSON = "json"
+	// FixtureJSONL is one OpenCDC record per line.
+	FixtureJSONL = "jsonl"
+	// FixtureConduit is the output of a Conduit file destination: one OpenCDC
+	// record per line, with JSON payloads written as raw data.
+	FixtureConduit = "conduit"
+	// FixtureCSV is a CSV file with a header row, each row becomes payload.after.
+	FixtureCSV = "csv"
+	// FixtureAvro is an Avro object container file, each record becomes payload.after.
+	FixtureAvro = "avro"
+	// FixtureSQL is a Postgres SQL dump, its statements become CDC records.
+	FixtureSQL = "sql"
+)
+
+// Sink types of local destinations.
+const (
+	SinkStdout  = "stdout"
+	SinkJSONL   = "jsonl"
+	SinkOpenCDC = "opencdc"
+	SinkSQLite  = "sqlite"
+	SinkMemory  = "memory"
+)
+
+type Destination struct {
+	// Type is one of the sink types, defaults to stdout.
+	Type string `json:"type"`
+	// Path is the file, directory or database written to, relative to the app.
+	Path string `json:"path,omitempty"`
+	// Table is the SQLite table written to, defaults to the destination name.
+	Table string `json:"table,omitempty"`
+	// Append keeps the records written by previous runs. By default, the
+	// file, directory or table is emptied on the first write of a run.
+	Append bool `json:"append,omitempty"`
+}
+
+func (d Destination) validate(name string) error {
+	switch d.Type {
+	case "", SinkStdout, SinkMemory:
+		return nil
+	case SinkJSONL, SinkOpenCDC, SinkSQLite:
+		if d.Path == "" {
+			return fmt.Errorf("destination %q requires a path for sink type %q", name, d.Type)
+		}
+		return nil
+	default:
+		return fmt.Errorf("destination %q has unknown sink type %q", name, d.Type)
+	}
 }
 
 // validateConfig will check if app.json contains information required.
@@ -22,6 +86,28 @@ func (c *Config) validateConfig() error {
 	if c.Name == "" {
 		return errors.New("application name is required to be specified in your app.json")
 	}
+	for name, f := range c.FixtureFormats {
+		switch f {
+		case FixtureJSON, FixtureJSONL, FixtureConduit, FixtureCSV, FixtureAvro, FixtureSQL:
+		default:
+			return fmt.Errorf("fixture %q has unknown format %q", name, f)
+		}
+	}
+	for name, d := range c.Destinations {
+		if err := d.validate(name); err != nil {
+			return err
+		}
+	}
+	for name, vars := range c.Profiles {
+		if name == "" {
+			return errors.New("profile names cannot be empty")
+		}
+		for v := range vars {
+			if err := ir.ValidatePlaceholderName(v); err != nil {
+				return fmt.Errorf("profile %q: %w", name, err)
+			}
+		}
+	}
 	return nil
 }
 
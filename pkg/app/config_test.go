@@ -36,6 +36,41 @@ func Test_ReadConfig(t *testing.T) {
 			appPath: setupAppJsonMissingField(t),
 			errmsg:  "application name is required",
 		},
+		{
+			desc:    "reads a valid app config with destination sinks",
+			appName: "testapp",
+			appPath: setupAppJsonWith(t, `"fixtures": {"source_name": "fixtures/demo-cdc.json"}, "destinations": {"s3": {"type": "jsonl", "path": "out/s3.jsonl"}, "pg": {"type": "memory"}}`),
+		},
+		{
+			desc:    "fails to read an config with an unknown sink type",
+			appPath: setupAppJsonWith(t, `"fixtures": {"source_name": "fixtures/demo-cdc.json"}, "destinations": {"s3": {"type": "kafka"}}`),
+			errmsg:  `destination "s3" has unknown sink type "kafka"`,
+		},
+		{
+			desc:    "fails to read an config with a sink missing its path",
+			appPath: setupAppJsonWith(t, `"fixtures": {"source_name": "fixtures/demo-cdc.json"}, "destinations": {"pg": {"type": "sqlite"}}`),
+			errmsg:  `destination "pg" requires a path for sink type "sqlite"`,
+		},
+		{
+			desc:    "reads a valid app config with fixture formats",
+			appName: "testapp",
+			appPath: setupAppJsonWith(t, `"fixtures": {"source_name": "fixtures/orders.csv"}, "fixture_formats": {"source_name": "csv"}`),
+		},
+		{
+			desc:    "fails to read an config with an unknown fixture format",
+			appPath: setupAppJsonWith(t, `"fixtures": {"source_name": "fixtures/orders.csv"}, "fixture_formats": {"source_name": "xml"}`),
+			errmsg:  `fixture "source_name" has unknown format "xml"`,
+		},
+		{
+			desc:    "reads a valid app config with profiles",
+			appName: "testapp",
+			appPath: setupAppJsonWith(t, `"profiles": {"dev": {"TABLE": "orders_dev"}, "prod": {"TABLE": "orders"}}`),
+		},
+		{
+			desc:    "fails to read an config with an invalid profile variable",
+			appPath: setupAppJsonWith(t, `"profiles": {"dev": {"table-name": "orders_dev"}}`),
+			errmsg:  `profile "dev": invalid placeholder name "table-name"`,
+		},
 		{
 			desc:    "fails to read bad app json",
 			appPath: setupBadAppJson(t),
@@ -99,6 +134,25 @@ func setupAppJsonWithDeprecatedFields(t *testing.T) string {
 	return tmpdir
 }
 
+// setupAppJsonWith writes an app.json holding the name and language of the
+// app along with the given fields, a comma separated list of JSON members.
+func setupAppJsonWith(t *testing.T, fields string) string {
+	tmpdir := t.TempDir()
+	if err := os.WriteFile(
+		path.Join(tmpdir, "app.json"),
+		[]byte(`{
+				  "name": "testapp",
+				  "language": "golang",
+				  `+fields+`
+				}`),
+		0o644,
+	); err != nil {
+		t.Fatal(err)
+	}
+
+	return tmpdir
+}
+
 func setupAppJsonMissingField(t *testing.T) string {
 	tmpdir := t.TempDir()
 	if err := os.WriteFile(
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DotEnvFile is the file next to app.json holding secrets for local runs.
+const DotEnvFile = ".env"
+
+// ReadDotEnv reads the variables of the .env file in the app directory. A
+// missing file yields no variables.
+//
+// Lines are KEY=VALUE pairs, optionally prefixed with "export". Blank lines
+// and lines starting with # are skipped. Values may be single quoted, taken
+// as is, or double quoted, where \n, \", \\ and friends are unescaped.
+// Unquoted values end at an inline " #" comment.
+func ReadDotEnv(appPath string) (map[string]string, error) {
+	file := filepath.Join(appPath, DotEnvFile)
+	f, err := os.Open(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("%s line %d: expected KEY=VALUE", file, n)
+		}
+
+		value, err := dotEnvValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", file, n, err)
+		}
+		vars[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return vars, nil
+}
+
+func dotEnvValue(v string) (string, error) {
+	switch {
+	case strings.HasPrefix(v, "'"):
+		end := strings.Index(v[1:], "'")
+		if end < 0 {
+			return "", errors.New("unterminated single quoted value")
+		}
+		return v[1 : end+1], nil
+	case strings.HasPrefix(v, `"`):
+		var b strings.Builder
+		for i := 1; i < len(v); i++ {
+			switch c := v[i]; c {
+			case '"':
+				return b.String(), nil
+			case '\\':
+				if i+1 == len(v) {
+					return "", errors.New("unterminated double quoted value")
+				}
+				i++
+				switch v[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(v[i])
+				}
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", errors.New("unterminated double quoted value")
+	default:
+		if i := strings.Index(v, " #"); i >= 0 {
+			v = v[:i]
+		}
+		return strings.TrimSpace(v), nil
+	}
+}
@@ -0,0 +1,70 @@
+package app_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/meroxa/turbine-core/v2/pkg/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadDotEnv(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		content string
+		want    map[string]string
+		errmsg  string
+	}{
+		{
+			desc: "reads variables",
+			content: `# local secrets
+PG_URL=postgres://app:p@ss@localhost/app
+export AWS_KEY = abc # inline comment
+
+SINGLE='a "quoted" \n value'
+DOUBLE="line\nbreak \"quoted\" # not a comment"
+EMPTY=
+`,
+			want: map[string]string{
+				"PG_URL":  "postgres://app:p@ss@localhost/app",
+				"AWS_KEY": "abc",
+				"SINGLE":  `a "quoted" \n value`,
+				"DOUBLE":  "line\nbreak \"quoted\" # not a comment",
+				"EMPTY":   "",
+			},
+		},
+		{
+			desc:    "fails on lines without a key",
+			content: "PG_URL=postgres\n=value\n",
+			errmsg:  ".env line 2: expected KEY=VALUE",
+		},
+		{
+			desc:    "fails on unterminated quotes",
+			content: `PG_URL="postgres`,
+			errmsg:  ".env line 1: unterminated double quoted value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, app.DotEnvFile), []byte(tc.content), 0o600))
+
+			got, err := app.ReadDotEnv(dir)
+			if tc.errmsg != "" {
+				assert.ErrorContains(t, err, tc.errmsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		got, err := app.ReadDotEnv(t.TempDir())
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
@@ -6,11 +6,20 @@ import (
 	"context"
 	"time"
 
+	"github.com/meroxa/turbine-core/v2/pkg/transport"
 	"github.com/meroxa/turbine-core/v2/proto/turbine/v2"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
+// SessionIDKey is the gRPC metadata key carrying the ID of a spec builder
+// recording session.
+const SessionIDKey = "turbine-session-id"
+
+// WarningKey is the gRPC header metadata key carrying the warnings of a call,
+// such as the unknown parameters of a plugin config added to a spec.
+const WarningKey = "turbine-warning"
+
 var _ Client = (*TurbineClient)(nil)
 
 type Client interface {
@@ -23,18 +32,43 @@ type TurbineClient struct {
 	turbinev2.ServiceClient
 }
 
-func DialTimeout(addr string, timeout time.Duration) (*TurbineClient, error) {
+type dialOptions struct {
+	tls transport.TLSConfig
+}
+
+type DialOption func(*dialOptions)
+
+// WithTLS secures the connection with TLS, see transport.TLSConfig.
+func WithTLS(cfg transport.TLSConfig) DialOption {
+	return func(o *dialOptions) {
+		o.tls = cfg
+	}
+}
+
+func DialTimeout(addr string, timeout time.Duration, opts ...DialOption) (*TurbineClient, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	return DialContext(ctx, addr)
+	return DialContext(ctx, addr, opts...)
 }
 
-func DialContext(ctx context.Context, addr string) (*TurbineClient, error) {
+// DialContext connects to the core server at addr, a host:port TCP address
+// or a unix:// socket path. Connections are insecure unless WithTLS is given.
+func DialContext(ctx context.Context, addr string, opts ...DialOption) (*TurbineClient, error) {
+	var o dialOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	creds, err := o.tls.ClientCredentials()
+	if err != nil {
+		return nil, err
+	}
+
 	c, err := grpc.DialContext(
 		ctx,
-		addr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		transport.Target(addr),
+		grpc.WithTransportCredentials(creds),
 	)
 	if err != nil {
 		return nil, err
@@ -49,3 +83,31 @@ func DialContext(ctx context.Context, addr string) (*TurbineClient, error) {
 func (c *TurbineClient) Close() {
 	c.ClientConn.Close()
 }
+
+// DialEnv connects to the core server at the address and with the TLS
+// settings of the environment, see transport.AddrFromEnv and
+// transport.TLSConfigFromEnv.
+func DialEnv(ctx context.Context) (*TurbineClient, error) {
+	return DialContext(ctx, transport.AddrFromEnv(), WithTLS(transport.TLSConfigFromEnv()))
+}
+
+// WithSessionID returns a context recording the calls made with it in the
+// given spec builder session.
+func WithSessionID(ctx context.Context, id string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, SessionIDKey, id)
+}
+
+// InitSession calls Init and returns a context carrying the ID of the
+// recording session the spec builder started, along with the ID.
+func InitSession(ctx context.Context, c turbinev2.ServiceClient, req *turbinev2.InitRequest) (context.Context, string, error) {
+	var header metadata.MD
+	if _, err := c.Init(ctx, req, grpc.Header(&header)); err != nil {
+		return nil, "", err
+	}
+
+	ids := header.Get(SessionIDKey)
+	if len(ids) == 0 {
+		return ctx, "", nil
+	}
+	return WithSessionID(ctx, ids[0]), ids[0], nil
+}
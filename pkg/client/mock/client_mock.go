@@ -129,6 +129,26 @@ func (mr *MockClientMockRecorder) Init(ctx, in interface{}, opts ...interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockClient)(nil).Init), varargs...)
 }
 
+// ListSessions mocks base method.
+func (m *MockClient) ListSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*turbinev2.ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSessions", varargs...)
+	ret0, _ := ret[0].(*turbinev2.ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockClientMockRecorder) ListSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockClient)(nil).ListSessions), varargs...)
+}
+
 // ProcessRecords mocks base method.
 func (m *MockClient) ProcessRecords(ctx context.Context, in *turbinev2.ProcessRecordsRequest, opts ...grpc.CallOption) (*turbinev2.ProcessRecordsResponse, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +189,46 @@ func (mr *MockClientMockRecorder) ReadRecords(ctx, in interface{}, opts ...inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRecords", reflect.TypeOf((*MockClient)(nil).ReadRecords), varargs...)
 }
 
+// ReadRecordsStream mocks base method.
+func (m *MockClient) ReadRecordsStream(ctx context.Context, in *turbinev2.ReadRecordsStreamRequest, opts ...grpc.CallOption) (turbinev2.Service_ReadRecordsStreamClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReadRecordsStream", varargs...)
+	ret0, _ := ret[0].(turbinev2.Service_ReadRecordsStreamClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadRecordsStream indicates an expected call of ReadRecordsStream.
+func (mr *MockClientMockRecorder) ReadRecordsStream(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadRecordsStream", reflect.TypeOf((*MockClient)(nil).ReadRecordsStream), varargs...)
+}
+
+// RegisterSecret mocks base method.
+func (m *MockClient) RegisterSecret(ctx context.Context, in *turbinev2.RegisterSecretRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RegisterSecret", varargs...)
+	ret0, _ := ret[0].(*emptypb.Empty)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterSecret indicates an expected call of RegisterSecret.
+func (mr *MockClientMockRecorder) RegisterSecret(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSecret", reflect.TypeOf((*MockClient)(nil).RegisterSecret), varargs...)
+}
+
 // WriteRecords mocks base method.
 func (m *MockClient) WriteRecords(ctx context.Context, in *turbinev2.WriteRecordsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	m.ctrl.T.Helper()
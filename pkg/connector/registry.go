@@ -0,0 +1,171 @@
+package connector
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:embed builtin/*.yaml
+var builtinFS embed.FS
+
+// Registry holds the specifications of connector plugins by name and
+// version. A specification without a version applies to every version of
+// its plugin that has no specification of its own.
+type Registry struct {
+	mu    sync.RWMutex
+	specs map[string]map[string]Specification
+}
+
+func NewRegistry() *Registry {
+	return &Registry{specs: make(map[string]map[string]Specification)}
+}
+
+// Builtin returns a new registry holding the specifications of the plugins
+// built into Conduit: file, generator, kafka, postgres and s3.
+func Builtin() *Registry {
+	r := NewRegistry()
+	files, err := builtinFS.ReadDir("builtin")
+	if err != nil {
+		panic(err)
+	}
+	for _, f := range files {
+		b, err := builtinFS.ReadFile(path.Join("builtin", f.Name()))
+		if err != nil {
+			panic(err)
+		}
+		specs, err := parseYAMLSpecs(b)
+		if err != nil {
+			panic(fmt.Errorf("builtin/%s: %w", f.Name(), err))
+		}
+		for _, s := range specs {
+			r.Register(s)
+		}
+	}
+	return r
+}
+
+// Register adds a specification, replacing any with the same plugin name
+// and version.
+func (r *Registry) Register(s Specification) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := PluginName(s.Name)
+	if r.specs[name] == nil {
+		r.specs[name] = make(map[string]Specification)
+	}
+	r.specs[name][normalizeVersion(s.Version)] = s
+}
+
+// Load registers the specifications of local JSON or YAML files, see
+// ReadFile.
+func (r *Registry) Load(files ...string) error {
+	for _, f := range files {
+		specs, err := ReadFile(f)
+		if err != nil {
+			return err
+		}
+		for _, s := range specs {
+			r.Register(s)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the specification of a plugin, see PluginName for the
+// accepted names. A plugin referenced with a version, e.g.
+// "builtin:postgres@v0.7.0", only matches the specification of that version
+// or one without a version. Otherwise, or with the "latest" version, the
+// specification without a version is preferred over the latest one.
+func (r *Registry) Lookup(plugin string) (Specification, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := r.specs[PluginName(plugin)]
+	version := normalizeVersion(PluginVersion(plugin))
+	if version != "" && version != "latest" {
+		if s, ok := versions[version]; ok {
+			return s, true
+		}
+	}
+	if s, ok := versions[""]; ok {
+		return s, true
+	}
+	if version != "" && version != "latest" {
+		return Specification{}, false
+	}
+
+	var (
+		latest Specification
+		found  bool
+	)
+	for v, s := range versions {
+		if !found || compareVersions(v, normalizeVersion(latest.Version)) > 0 {
+			latest, found = s, true
+		}
+	}
+	return latest, found
+}
+
+// Plugins returns the sorted names of the registered plugins.
+func (r *Registry) Plugins() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.specs))
+	for name := range r.specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PluginVersion returns the version a plugin is referenced with, e.g.
+// "v0.7.0" for "builtin:postgres@v0.7.0", or "" when it has none.
+func PluginVersion(plugin string) string {
+	_, version, _ := strings.Cut(plugin, "@")
+	return version
+}
+
+func normalizeVersion(v string) string {
+	return strings.TrimPrefix(strings.ToLower(v), "v")
+}
+
+// compareVersions compares dot separated versions numerically where
+// possible, e.g. 0.10.0 is greater than 0.9.1.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		an, aErr := strconv.Atoi(as[i])
+		bn, bErr := strconv.Atoi(bs[i])
+		switch {
+		case aErr == nil && bErr == nil && an != bn:
+			if an < bn {
+				return -1
+			}
+			return 1
+		case (aErr != nil || bErr != nil) && as[i] != bs[i]:
+			return strings.Compare(as[i], bs[i])
+		}
+	}
+	return len(as) - len(bs)
+}
+
+// PluginName returns the bare name of a plugin, as referenced by apps or
+// Conduit: "builtin:postgres@v0.7.0", "github.com/conduitio/conduit-connector-postgres"
+// and "postgres" are all named "postgres".
+func PluginName(plugin string) string {
+	name := plugin
+	if _, n, ok := strings.Cut(name, ":"); ok {
+		name = n
+	}
+	if n, _, ok := strings.Cut(name, "@"); ok {
+		name = n
+	}
+	name = path.Base(name)
+	return strings.ToLower(strings.TrimPrefix(name, "conduit-connector-"))
+}
@@ -0,0 +1,85 @@
+package connector_test
+
+import (
+	"testing"
+
+	"github.com/meroxa/turbine-core/v2/pkg/connector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Builtin(t *testing.T) {
+	r := connector.Builtin()
+	assert.Equal(t, []string{"file", "generator", "kafka", "postgres", "s3"}, r.Plugins())
+
+	spec, ok := r.Lookup("builtin:postgres@v0.7.0")
+	require.True(t, ok)
+	assert.Equal(t, "postgres", spec.Name)
+	assert.Contains(t, spec.SourceParams, "url")
+
+	generator, ok := r.Lookup("generator")
+	require.True(t, ok)
+	assert.Nil(t, generator.DestinationParams)
+}
+
+func Test_Registry_Load(t *testing.T) {
+	r := connector.Builtin()
+	require.NoError(t, r.Load(writeFile(t, "specs.yaml", `
+- name: mysql
+  sourceParams: {}
+- name: postgres
+  summary: patched postgres
+  sourceParams: {}
+`)))
+
+	_, ok := r.Lookup("standalone:mysql")
+	assert.True(t, ok)
+	spec, ok := r.Lookup("postgres")
+	require.True(t, ok)
+	assert.Equal(t, "patched postgres", spec.Summary)
+
+	assert.Error(t, r.Load("missing.yaml"))
+}
+
+func Test_Registry_Versions(t *testing.T) {
+	r := connector.NewRegistry()
+	r.Register(connector.Specification{Name: "mysql", Version: "v0.9.0", Summary: "0.9"})
+	r.Register(connector.Specification{Name: "mysql", Version: "v0.10.0", Summary: "0.10"})
+
+	for plugin, want := range map[string]string{
+		"mysql":                        "0.10",
+		"standalone:mysql@latest":      "0.10",
+		"standalone:mysql@v0.9.0":      "0.9",
+		"standalone:mysql@0.9.0":       "0.9",
+		"standalone:mysql@v0.10.0":     "0.10",
+		"standalone:mysql@v1.0.0":      "",
+		"github.com/foo/mysql@v0.11.0": "",
+	} {
+		spec, ok := r.Lookup(plugin)
+		assert.Equal(t, want != "", ok, plugin)
+		assert.Equal(t, want, spec.Summary, plugin)
+	}
+
+	// A specification without a version applies to the other versions.
+	r.Register(connector.Specification{Name: "mysql", Summary: "any"})
+	for plugin, want := range map[string]string{
+		"mysql":                   "any",
+		"standalone:mysql@v0.9.0": "0.9",
+		"standalone:mysql@v1.0.0": "any",
+	} {
+		spec, ok := r.Lookup(plugin)
+		assert.True(t, ok, plugin)
+		assert.Equal(t, want, spec.Summary, plugin)
+	}
+}
+
+func Test_PluginName(t *testing.T) {
+	for in, want := range map[string]string{
+		"postgres":                                  "postgres",
+		"builtin:postgres@v0.7.0":                   "postgres",
+		"standalone:my-plugin@latest":               "my-plugin",
+		"github.com/conduitio/conduit-connector-S3": "s3",
+	} {
+		assert.Equal(t, want, connector.PluginName(in), in)
+	}
+}
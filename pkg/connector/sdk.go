@@ -0,0 +1,106 @@
+package connector
+
+import (
+	"github.com/conduitio/conduit-commons/config"
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+)
+
+// sdkSourceParams are the parameters the Conduit connector SDK adds to the
+// source of every plugin.
+var sdkSourceParams = config.Parameters{
+	"sdk.batch.size": {
+		Description: "Maximum size of a batch of records before it is sent to Conduit.",
+		Type:        config.ParameterTypeInt,
+		Default:     "0",
+	},
+	"sdk.batch.delay": {
+		Description: "Maximum delay before an incomplete batch is sent to Conduit.",
+		Type:        config.ParameterTypeDuration,
+		Default:     "0",
+	},
+	"sdk.schema.extract.type": {
+		Description: "The type of the schema extracted from the records.",
+		Type:        config.ParameterTypeString,
+		Default:     "avro",
+	},
+	"sdk.schema.extract.key.enabled": {
+		Description: "Whether to extract and encode the schema of the record key.",
+		Type:        config.ParameterTypeBool,
+		Default:     "true",
+	},
+	"sdk.schema.extract.key.subject": {
+		Description: "The subject of the schema of the record key.",
+		Type:        config.ParameterTypeString,
+		Default:     "key",
+	},
+	"sdk.schema.extract.payload.enabled": {
+		Description: "Whether to extract and encode the schema of the record payload.",
+		Type:        config.ParameterTypeBool,
+		Default:     "true",
+	},
+	"sdk.schema.extract.payload.subject": {
+		Description: "The subject of the schema of the record payload.",
+		Type:        config.ParameterTypeString,
+		Default:     "payload",
+	},
+	"sdk.schema.context.enabled": {
+		Description: "Whether to use a schema context name.",
+		Type:        config.ParameterTypeBool,
+		Default:     "true",
+	},
+	"sdk.schema.context.name": {
+		Description: "Schema context name to be used.",
+		Type:        config.ParameterTypeString,
+	},
+}
+
+// sdkDestinationParams are the parameters the Conduit connector SDK adds to
+// the destination of every plugin.
+var sdkDestinationParams = config.Parameters{
+	"sdk.batch.size": {
+		Description: "Maximum size of a batch of records before it is written.",
+		Type:        config.ParameterTypeInt,
+		Default:     "0",
+	},
+	"sdk.batch.delay": {
+		Description: "Maximum delay before an incomplete batch is written.",
+		Type:        config.ParameterTypeDuration,
+		Default:     "0",
+	},
+	"sdk.rate.perSecond": {
+		Description: "Maximum number of records written per second, unlimited when 0.",
+		Type:        config.ParameterTypeFloat,
+		Default:     "0",
+	},
+	"sdk.rate.burst": {
+		Description: "Number of records that can be written at once regardless of the rate.",
+		Type:        config.ParameterTypeInt,
+		Default:     "0",
+	},
+	"sdk.record.format": {
+		Description: "The format of the records written, e.g. opencdc/json.",
+		Type:        config.ParameterTypeString,
+		Default:     "opencdc/json",
+	},
+	"sdk.record.format.options": {
+		Description: "Options of the record format.",
+		Type:        config.ParameterTypeString,
+	},
+	"sdk.schema.extract.key.enabled": {
+		Description: "Whether to decode the record key using its schema.",
+		Type:        config.ParameterTypeBool,
+		Default:     "true",
+	},
+	"sdk.schema.extract.payload.enabled": {
+		Description: "Whether to decode the record payload using its schema.",
+		Type:        config.ParameterTypeBool,
+		Default:     "true",
+	},
+}
+
+func sdkParams(direction ir.DirectionType) config.Parameters {
+	if direction == ir.PluginDestination {
+		return sdkDestinationParams
+	}
+	return sdkSourceParams
+}
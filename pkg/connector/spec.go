@@ -0,0 +1,253 @@
+// Package connector describes the configuration parameters of connector
+// plugins and validates plugin configs against them.
+package connector
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/config"
+	"gopkg.in/yaml.v3"
+)
+
+// Specification describes a connector plugin and the parameters of its
+// source and destination, shaped like the specification of Conduit plugins.
+// A nil SourceParams or DestinationParams means the plugin cannot be used in
+// that direction.
+type Specification struct {
+	Name              string
+	Summary           string
+	Description       string
+	Version           string
+	Author            string
+	SourceParams      config.Parameters
+	DestinationParams config.Parameters
+}
+
+// specFile is the JSON and YAML form of a Specification, as exported by
+// Conduit.
+type specFile struct {
+	Name              string               `json:"name"`
+	Summary           string               `json:"summary,omitempty"`
+	Description       string               `json:"description,omitempty"`
+	Version           string               `json:"version,omitempty"`
+	Author            string               `json:"author,omitempty"`
+	SourceParams      map[string]paramFile `json:"sourceParams,omitempty"`
+	DestinationParams map[string]paramFile `json:"destinationParams,omitempty"`
+}
+
+type paramFile struct {
+	Default     scalar           `json:"default,omitempty"`
+	Description string           `json:"description,omitempty"`
+	Type        string           `json:"type,omitempty"`
+	Validations []validationFile `json:"validations,omitempty"`
+}
+
+type validationFile struct {
+	Type  string `json:"type"`
+	Value scalar `json:"value,omitempty"`
+}
+
+// scalar is a string that may also be written as a number or a boolean, as
+// YAML files do for values such as `default: 10`.
+type scalar string
+
+func (s *scalar) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case nil:
+		*s = ""
+	case string:
+		*s = scalar(v)
+	case float64, bool:
+		*s = scalar(strings.TrimSpace(string(b)))
+	default:
+		return fmt.Errorf("expected a string, got %s", b)
+	}
+	return nil
+}
+
+// ReadFile reads the specifications of a JSON or YAML file, holding either a
+// single specification or a list of them.
+func ReadFile(file string) ([]Specification, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var specs []Specification
+	switch filepath.Ext(file) {
+	case ".json":
+		specs, err = parseSpecs(b)
+	case ".yaml", ".yml":
+		specs, err = parseYAMLSpecs(b)
+	default:
+		return nil, fmt.Errorf("unsupported plugin specification file %s, use .json, .yaml or .yml", file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return specs, nil
+}
+
+// parseYAMLSpecs converts YAML to JSON before parsing it, specifications only
+// hold JSON compatible values.
+func parseYAMLSpecs(b []byte) ([]Specification, error) {
+	var v interface{}
+	if err := yaml.Unmarshal(b, &v); err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return parseSpecs(b)
+}
+
+func parseSpecs(b []byte) ([]Specification, error) {
+	var files []specFile
+	if trimmed := strings.TrimSpace(string(b)); strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal(b, &files); err != nil {
+			return nil, err
+		}
+	} else {
+		var f specFile
+		if err := json.Unmarshal(b, &f); err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	specs := make([]Specification, 0, len(files))
+	for _, f := range files {
+		s, err := f.specification()
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, s)
+	}
+	return specs, nil
+}
+
+func (f specFile) specification() (Specification, error) {
+	if f.Name == "" {
+		return Specification{}, errors.New("plugin specification without a name")
+	}
+
+	s := Specification{
+		Name:        f.Name,
+		Summary:     f.Summary,
+		Description: f.Description,
+		Version:     f.Version,
+		Author:      f.Author,
+	}
+
+	var err error
+	if s.SourceParams, err = parameters(f.SourceParams); err != nil {
+		return Specification{}, fmt.Errorf("plugin %s source: %w", f.Name, err)
+	}
+	if s.DestinationParams, err = parameters(f.DestinationParams); err != nil {
+		return Specification{}, fmt.Errorf("plugin %s destination: %w", f.Name, err)
+	}
+	return s, nil
+}
+
+func parameters(files map[string]paramFile) (config.Parameters, error) {
+	if files == nil {
+		return nil, nil
+	}
+
+	params := make(config.Parameters, len(files))
+	for name, f := range files {
+		t, err := parameterType(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", name, err)
+		}
+
+		p := config.Parameter{
+			Default:     string(f.Default),
+			Description: f.Description,
+			Type:        t,
+		}
+		for _, v := range f.Validations {
+			validation, err := parseValidation(v)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %q: %w", name, err)
+			}
+			p.Validations = append(p.Validations, validation)
+		}
+		params[name] = p
+	}
+	return params, nil
+}
+
+// normalizeEnum accepts both the names used by the Go SDK, e.g. "greater-than",
+// and the ones of the Conduit API, e.g. "TYPE_GREATER_THAN".
+func normalizeEnum(s string) string {
+	s = strings.TrimPrefix(s, "TYPE_")
+	return strings.ReplaceAll(strings.ToLower(s), "_", "-")
+}
+
+func parameterType(s string) (config.ParameterType, error) {
+	switch normalizeEnum(s) {
+	case "", "string":
+		return config.ParameterTypeString, nil
+	case "int":
+		return config.ParameterTypeInt, nil
+	case "float":
+		return config.ParameterTypeFloat, nil
+	case "bool":
+		return config.ParameterTypeBool, nil
+	case "file":
+		return config.ParameterTypeFile, nil
+	case "duration":
+		return config.ParameterTypeDuration, nil
+	default:
+		return 0, fmt.Errorf("unknown parameter type %q", s)
+	}
+}
+
+func parseValidation(v validationFile) (config.Validation, error) {
+	list := func() []string {
+		items := strings.Split(string(v.Value), ",")
+		for i := range items {
+			items[i] = strings.TrimSpace(items[i])
+		}
+		return items
+	}
+
+	switch normalizeEnum(v.Type) {
+	case "required":
+		return config.ValidationRequired{}, nil
+	case "greater-than", "less-than":
+		n, err := strconv.ParseFloat(string(v.Value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s validation requires a number, got %q", v.Type, v.Value)
+		}
+		if normalizeEnum(v.Type) == "greater-than" {
+			return config.ValidationGreaterThan{V: n}, nil
+		}
+		return config.ValidationLessThan{V: n}, nil
+	case "inclusion":
+		return config.ValidationInclusion{List: list()}, nil
+	case "exclusion":
+		return config.ValidationExclusion{List: list()}, nil
+	case "regex":
+		re, err := regexp.Compile(string(v.Value))
+		if err != nil {
+			return nil, fmt.Errorf("regex validation: %w", err)
+		}
+		return config.ValidationRegex{Regex: re}, nil
+	default:
+		return nil, fmt.Errorf("unknown validation type %q", v.Type)
+	}
+}
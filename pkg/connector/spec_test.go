@@ -0,0 +1,161 @@
+package connector_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/config"
+	"github.com/meroxa/turbine-core/v2/pkg/connector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+	return file
+}
+
+func Test_ReadFile(t *testing.T) {
+	want := []connector.Specification{
+		{
+			Name:    "mysql",
+			Version: "v0.1.0",
+			SourceParams: config.Parameters{
+				"url": {
+					Description: "database URL",
+					Type:        config.ParameterTypeString,
+					Validations: []config.Validation{config.ValidationRequired{}},
+				},
+				"fetchSize": {
+					Default: "1000",
+					Type:    config.ParameterTypeInt,
+					Validations: []config.Validation{
+						config.ValidationGreaterThan{V: 0},
+						config.ValidationLessThan{V: 100000},
+					},
+				},
+				"table": {
+					Type:        config.ParameterTypeString,
+					Validations: []config.Validation{config.ValidationRegex{Regex: regexp.MustCompile(`^\w+$`)}},
+				},
+			},
+			DestinationParams: config.Parameters{
+				"mode": {
+					Default:     "upsert",
+					Type:        config.ParameterTypeString,
+					Validations: []config.Validation{config.ValidationInclusion{List: []string{"insert", "upsert"}}},
+				},
+			},
+		},
+	}
+
+	testCases := []struct {
+		desc    string
+		file    string
+		content string
+	}{
+		{
+			desc: "json as exported by the Conduit API",
+			file: "mysql.json",
+			content: `[{
+  "name": "mysql",
+  "version": "v0.1.0",
+  "sourceParams": {
+    "url": {"description": "database URL", "type": "TYPE_STRING", "validations": [{"type": "TYPE_REQUIRED"}]},
+    "fetchSize": {"default": "1000", "type": "TYPE_INT", "validations": [
+      {"type": "TYPE_GREATER_THAN", "value": "0"},
+      {"type": "TYPE_LESS_THAN", "value": "100000"}
+    ]},
+    "table": {"type": "TYPE_STRING", "validations": [{"type": "TYPE_REGEX", "value": "^\\w+$"}]}
+  },
+  "destinationParams": {
+    "mode": {"default": "upsert", "type": "TYPE_STRING", "validations": [{"type": "TYPE_INCLUSION", "value": "insert,upsert"}]}
+  }
+}]`,
+		},
+		{
+			desc: "yaml",
+			file: "mysql.yml",
+			content: `name: mysql
+version: v0.1.0
+sourceParams:
+  url:
+    description: database URL
+    validations:
+      - type: required
+  fetchSize:
+    default: 1000
+    type: int
+    validations:
+      - {type: greater-than, value: 0}
+      - {type: less-than, value: 100000}
+  table:
+    validations:
+      - {type: regex, value: '^\w+$'}
+destinationParams:
+  mode:
+    default: upsert
+    validations:
+      - {type: inclusion, value: "insert, upsert"}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := connector.ReadFile(writeFile(t, tc.file, tc.content))
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func Test_ReadFile_Errors(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		file    string
+		content string
+		errmsg  string
+	}{
+		{
+			desc:   "unsupported extension",
+			file:   "mysql.toml",
+			errmsg: "unsupported plugin specification file",
+		},
+		{
+			desc:    "missing name",
+			file:    "mysql.yaml",
+			content: "sourceParams: {}",
+			errmsg:  "plugin specification without a name",
+		},
+		{
+			desc:    "unknown type",
+			file:    "mysql.yaml",
+			content: "name: mysql\nsourceParams:\n  port: {type: integer}",
+			errmsg:  `plugin mysql source: parameter "port": unknown parameter type "integer"`,
+		},
+		{
+			desc:    "unknown validation",
+			file:    "mysql.json",
+			content: `{"name": "mysql", "destinationParams": {"url": {"validations": [{"type": "email"}]}}}`,
+			errmsg:  `plugin mysql destination: parameter "url": unknown validation type "email"`,
+		},
+		{
+			desc:    "invalid bound",
+			file:    "mysql.yaml",
+			content: "name: mysql\nsourceParams:\n  port: {type: int, validations: [{type: less-than, value: many}]}",
+			errmsg:  `less-than validation requires a number, got "many"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := connector.ReadFile(writeFile(t, tc.file, tc.content))
+			assert.ErrorContains(t, err, tc.errmsg)
+		})
+	}
+}
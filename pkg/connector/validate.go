@@ -0,0 +1,256 @@
+package connector
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conduitio/conduit-commons/config"
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+)
+
+// Violation is a problem with a plugin config, Parameter is empty when it
+// concerns the config as a whole.
+type Violation struct {
+	Parameter string
+	Message   string
+	// Warning is set when the violation does not make the config invalid,
+	// such as an unknown parameter which the plugin may still support.
+	Warning bool
+}
+
+// ConfigError reports every violation of a plugin config.
+type ConfigError struct {
+	Plugin     string
+	Direction  ir.DirectionType
+	Violations []Violation
+}
+
+// HasErrors reports whether a violation makes the config invalid, rather
+// than only warranting a warning.
+func (e *ConfigError) HasErrors() bool {
+	for _, v := range e.Violations {
+		if !v.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *ConfigError) Error() string {
+	msgs := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		msgs[i] = v.Message
+	}
+	return fmt.Sprintf("invalid %s %s config: %s", e.Plugin, e.Direction, strings.Join(msgs, "; "))
+}
+
+// Validate checks a plugin config against the specification of the plugin,
+// see Lookup, plugins without one are not validated. Besides the parameters
+// of the specification, the sdk.* parameters of the Conduit connector SDK
+// are accepted. Unknown parameters, missing required ones, values of the
+// wrong type and values failing the validations of their parameter are
+// reported, in that order, as a *ConfigError. Unknown parameters are only
+// warnings, as specifications may lag behind the plugins, see
+// ConfigError.HasErrors.
+//
+// Values holding placeholders or secret references are only resolved when
+// the app runs, their type and validations are not checked.
+func (r *Registry) Validate(plugin string, direction ir.DirectionType, cfg map[string]string) error {
+	spec, ok := r.Lookup(plugin)
+	if !ok {
+		return nil
+	}
+
+	params := spec.SourceParams
+	if direction == ir.PluginDestination {
+		params = spec.DestinationParams
+	}
+	name := PluginName(plugin)
+	if params == nil {
+		return &ConfigError{
+			Plugin:    name,
+			Direction: direction,
+			Violations: []Violation{
+				{Message: fmt.Sprintf("plugin %s cannot be used as a %s", name, direction)},
+			},
+		}
+	}
+
+	var unknown, missing, invalid []Violation
+	for _, key := range sortedKeys(cfg) {
+		p, ok := lookupParam(params, key)
+		if !ok {
+			p, ok = sdkParams(direction)[key]
+		}
+		if !ok {
+			msg := fmt.Sprintf("unknown parameter %q", key)
+			if s := suggest(params, key); s != "" {
+				msg += fmt.Sprintf(", did you mean %q?", s)
+			}
+			unknown = append(unknown, Violation{Parameter: key, Message: msg, Warning: true})
+			continue
+		}
+
+		value := cfg[key]
+		if value == "" || isTemplated(value) {
+			continue
+		}
+		if err := validateValue(p, value); err != nil {
+			invalid = append(invalid, Violation{
+				Parameter: key,
+				Message:   fmt.Sprintf("parameter %q: %s", key, err),
+			})
+		}
+	}
+
+	for _, key := range sortedKeys(params) {
+		p := params[key]
+		if strings.Contains(key, "*") || !isRequired(p) || p.Default != "" || cfg[key] != "" {
+			continue
+		}
+		missing = append(missing, Violation{
+			Parameter: key,
+			Message:   fmt.Sprintf("required parameter %q is not set", key),
+		})
+	}
+
+	violations := append(append(unknown, missing...), invalid...)
+	if len(violations) == 0 {
+		return nil
+	}
+	return &ConfigError{Plugin: name, Direction: direction, Violations: violations}
+}
+
+func isRequired(p config.Parameter) bool {
+	for _, v := range p.Validations {
+		if _, ok := v.(config.ValidationRequired); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isTemplated reports whether a value holds placeholders or secret
+// references.
+func isTemplated(value string) bool {
+	return len(ir.Placeholders(value)) > 0 || len(ir.SecretRefs(value)) > 0
+}
+
+func validateValue(p config.Parameter, value string) error {
+	var err error
+	switch p.Type {
+	case config.ParameterTypeInt:
+		_, err = strconv.Atoi(value)
+	case config.ParameterTypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case config.ParameterTypeBool:
+		_, err = strconv.ParseBool(value)
+	case config.ParameterTypeDuration:
+		_, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return fmt.Errorf("%q is not a valid %s", value, p.Type)
+	}
+
+	for _, v := range p.Validations {
+		if err := v.Validate(value); err != nil {
+			// Drop the sentinel error wrapped by the config package, e.g.
+			// ": inclusion validation failed".
+			if wrapped := errors.Unwrap(err); wrapped != nil {
+				return errors.New(strings.TrimSuffix(err.Error(), ": "+wrapped.Error()))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupParam returns the parameter of a config key. A * segment of a
+// parameter name matches any segment of the key, a trailing one matches the
+// rest of the key, e.g. "format.options.*" matches "format.options.id".
+func lookupParam(params config.Parameters, key string) (config.Parameter, bool) {
+	if p, ok := params[key]; ok {
+		return p, true
+	}
+	for name, p := range params {
+		if strings.Contains(name, "*") && matchWildcard(name, key) {
+			return p, true
+		}
+	}
+	return config.Parameter{}, false
+}
+
+func matchWildcard(pattern, key string) bool {
+	ps := strings.Split(pattern, ".")
+	ks := strings.Split(key, ".")
+	for i, p := range ps {
+		if i == len(ks) {
+			return false
+		}
+		if p == "*" && i == len(ps)-1 {
+			return true
+		}
+		if p != "*" && p != ks[i] {
+			return false
+		}
+	}
+	return len(ps) == len(ks)
+}
+
+// suggest returns the parameter closest to an unknown key, if it is close
+// enough to be a typo.
+func suggest(params config.Parameters, key string) string {
+	best, bestDist := "", -1
+	for _, name := range sortedKeys(params) {
+		if strings.Contains(name, "*") {
+			continue
+		}
+		d := editDistance(strings.ToLower(key), strings.ToLower(name))
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = name, d
+		}
+	}
+	if bestDist < 0 || (bestDist > 2 && bestDist*3 > len(key)) {
+		return ""
+	}
+	return best
+}
+
+// editDistance is the optimal string alignment distance of a and b, the
+// Levenshtein distance counting adjacent transpositions as a single edit.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,128 @@
+package ir
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// canonicalJSON returns the canonical form of the spec: resources are listed in
+// topological order, ties broken by name and UUID, streams are ordered by their
+// endpoints, empty collections are normalized and every object key is sorted.
+// The spec must hold a valid DAG.
+func (d *DeploymentSpec) canonicalJSON() ([]byte, error) {
+	order := d.topologicalOrder()
+
+	c := &DeploymentSpec{
+		Connectors: make([]ConnectorSpec, 0, len(d.Connectors)),
+		Definition: d.Definition,
+	}
+	if len(d.Secrets) > 0 {
+		c.Secrets = d.Secrets
+	}
+	for _, con := range d.Connectors {
+		if len(con.PluginConfig) == 0 {
+			con.PluginConfig = nil
+		}
+		c.Connectors = append(c.Connectors, con)
+	}
+	sort.SliceStable(c.Connectors, func(i, j int) bool {
+		return order[c.Connectors[i].UUID] < order[c.Connectors[j].UUID]
+	})
+
+	if len(d.Functions) > 0 {
+		c.Functions = append(c.Functions, d.Functions...)
+		sort.SliceStable(c.Functions, func(i, j int) bool {
+			return order[c.Functions[i].UUID] < order[c.Functions[j].UUID]
+		})
+	}
+
+	if len(d.Streams) > 0 {
+		c.Streams = append(c.Streams, d.Streams...)
+		sort.SliceStable(c.Streams, func(i, j int) bool {
+			a, b := c.Streams[i], c.Streams[j]
+			if order[a.FromUUID] != order[b.FromUUID] {
+				return order[a.FromUUID] < order[b.FromUUID]
+			}
+			return order[a.ToUUID] < order[b.ToUUID]
+		})
+	}
+
+	out, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Round trip through generic values, encoding/json sorts map keys.
+	var v interface{}
+	if err := json.Unmarshal(out, &v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+// topologicalOrder returns the position of every connector and function in a
+// topological order of the spec, choosing the resource with the lowest name and
+// UUID whenever several are ready.
+func (d *DeploymentSpec) topologicalOrder() map[string]int {
+	type node struct{ id, name string }
+
+	var nodes []node
+	for _, c := range d.Connectors {
+		nodes = append(nodes, node{id: c.UUID, name: c.Name})
+	}
+	for _, f := range d.Functions {
+		nodes = append(nodes, node{id: f.UUID, name: f.Name})
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].name != nodes[j].name {
+			return nodes[i].name < nodes[j].name
+		}
+		return nodes[i].id < nodes[j].id
+	})
+
+	inDegree := make(map[string]int, len(nodes))
+	children := make(map[string][]string, len(nodes))
+	for _, s := range d.Streams {
+		inDegree[s.ToUUID]++
+		children[s.FromUUID] = append(children[s.FromUUID], s.ToUUID)
+	}
+
+	order := make(map[string]int, len(nodes))
+	for len(order) < len(nodes) {
+		progressed := false
+		for _, n := range nodes {
+			if _, done := order[n.id]; done || inDegree[n.id] > 0 {
+				continue
+			}
+			order[n.id] = len(order)
+			for _, child := range children[n.id] {
+				inDegree[child]--
+			}
+			progressed = true
+			break
+		}
+		if !progressed {
+			// Not a DAG, keep the remaining resources in name order.
+			for _, n := range nodes {
+				if _, done := order[n.id]; !done {
+					order[n.id] = len(order)
+				}
+			}
+		}
+	}
+	return order
+}
+
+// Hash returns the SHA-256 of the canonical JSON form of the spec, prefixed with
+// "sha256:". Specs describing the same deployment have the same hash regardless
+// of the order in which their resources were added.
+func (d *DeploymentSpec) Hash() (string, error) {
+	spec, err := d.Marshal()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(spec)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
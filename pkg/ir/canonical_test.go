@@ -0,0 +1,84 @@
+package ir_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Marshal_Canonical(t *testing.T) {
+	definition := ir.DefinitionSpec{
+		GitSha: "gitsha",
+		Metadata: ir.MetadataSpec{
+			Turbine:     ir.TurbineSpec{Language: ir.GoLang, Version: "0.1.0"},
+			SpecVersion: ir.LatestSpecVersion,
+		},
+	}
+
+	a := &ir.DeploymentSpec{
+		Definition: definition,
+		Connectors: []ir.ConnectorSpec{
+			{UUID: "1", Name: "pg", PluginType: ir.PluginSource, PluginName: "postgres", PluginConfig: map[string]string{"b": "2", "a": "1"}},
+			{UUID: "2", Name: "s3", PluginType: ir.PluginDestination, PluginName: "s3", PluginConfig: map[string]string{}},
+		},
+		Functions: []ir.FunctionSpec{{UUID: "3", Name: "anonymize", Image: "app"}},
+		Streams: []ir.StreamSpec{
+			{UUID: "1_3", Name: "1_3", FromUUID: "1", ToUUID: "3"},
+			{UUID: "3_2", Name: "3_2", FromUUID: "3", ToUUID: "2"},
+		},
+	}
+	b := &ir.DeploymentSpec{
+		Definition: definition,
+		Connectors: []ir.ConnectorSpec{
+			{UUID: "2", Name: "s3", PluginType: ir.PluginDestination, PluginName: "s3"},
+			{UUID: "1", Name: "pg", PluginType: ir.PluginSource, PluginName: "postgres", PluginConfig: map[string]string{"a": "1", "b": "2"}},
+		},
+		Functions: []ir.FunctionSpec{{UUID: "3", Name: "anonymize", Image: "app"}},
+		Streams: []ir.StreamSpec{
+			{UUID: "3_2", Name: "3_2", FromUUID: "3", ToUUID: "2"},
+			{UUID: "1_3", Name: "1_3", FromUUID: "1", ToUUID: "3"},
+		},
+	}
+
+	gotA, err := a.Marshal()
+	require.NoError(t, err)
+	gotB, err := b.Marshal()
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"connectors":[`+
+		`{"name":"pg","plugin_config":{"a":"1","b":"2"},"plugin_name":"postgres","plugin_type":"source","uuid":"1"},`+
+		`{"name":"s3","plugin_name":"s3","plugin_type":"destination","uuid":"2"}],`+
+		`"definition":{"git_sha":"gitsha","metadata":{"spec_version":"v3","turbine":{"language":"golang","version":"0.1.0"}}},`+
+		`"functions":[{"image":"app","name":"anonymize","uuid":"3"}],`+
+		`"streams":[{"from_uuid":"1","name":"1_3","to_uuid":"3","uuid":"1_3"},{"from_uuid":"3","name":"3_2","to_uuid":"2","uuid":"3_2"}]}`,
+		string(gotA))
+	assert.Equal(t, string(gotA), string(gotB))
+
+	// Marshal does not reorder the spec itself.
+	assert.Equal(t, "s3", b.Connectors[0].Name)
+
+	hashA, err := a.Hash()
+	require.NoError(t, err)
+	hashB, err := b.Hash()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hashA, "sha256:"))
+	assert.Equal(t, hashA, hashB)
+
+	b.Functions[0].Image = "app:2"
+	hashB, err = b.Hash()
+	require.NoError(t, err)
+	assert.NotEqual(t, hashA, hashB)
+}
+
+func Test_Marshal_EmptyCollections(t *testing.T) {
+	got, err := (&ir.DeploymentSpec{
+		Definition: ir.DefinitionSpec{Metadata: ir.MetadataSpec{SpecVersion: ir.LatestSpecVersion}},
+		Functions:  []ir.FunctionSpec{},
+		Streams:    []ir.StreamSpec{},
+	}).Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, `{"connectors":[],"definition":{"git_sha":"","metadata":{"spec_version":"v3","turbine":{"language":"","version":""}}}}`, string(got))
+}
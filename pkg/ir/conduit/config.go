@@ -0,0 +1,51 @@
+// Package conduit converts deployment specs from and to Conduit pipeline
+// configuration files.
+package conduit
+
+// PipelineConfigVersion is the version of the Conduit pipeline configuration
+// files written by Export.
+const PipelineConfigVersion = "2.0"
+
+// Configuration is a Conduit pipeline configuration file.
+type Configuration struct {
+	Version   string     `yaml:"version"`
+	Pipelines []Pipeline `yaml:"pipelines"`
+}
+
+type Pipeline struct {
+	ID          string      `yaml:"id"`
+	Status      string      `yaml:"status,omitempty"`
+	Name        string      `yaml:"name,omitempty"`
+	Description string      `yaml:"description,omitempty"`
+	Connectors  []Connector `yaml:"connectors,omitempty"`
+	Processors  []Processor `yaml:"processors,omitempty"`
+	// DLQ configures the dead-letter queue, it has no equivalent in a spec.
+	DLQ map[string]interface{} `yaml:"dlq,omitempty"`
+}
+
+type Connector struct {
+	ID         string            `yaml:"id"`
+	Type       string            `yaml:"type"`
+	Plugin     string            `yaml:"plugin"`
+	Name       string            `yaml:"name,omitempty"`
+	Settings   map[string]string `yaml:"settings,omitempty"`
+	Processors []Processor       `yaml:"processors,omitempty"`
+}
+
+type Processor struct {
+	ID        string            `yaml:"id"`
+	Plugin    string            `yaml:"plugin"`
+	Condition string            `yaml:"condition,omitempty"`
+	Settings  map[string]string `yaml:"settings,omitempty"`
+	Workers   int               `yaml:"workers,omitempty"`
+}
+
+const (
+	StatusRunning = "running"
+
+	TypeSource      = "source"
+	TypeDestination = "destination"
+
+	// builtinPrefix is prepended to plugin names which do not name a plugin type.
+	builtinPrefix = "builtin:"
+)
@@ -0,0 +1,275 @@
+package conduit
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+	"gopkg.in/yaml.v3"
+)
+
+// ExportOptions control how a spec is rendered as a pipeline configuration.
+type ExportOptions struct {
+	// PipelineID is the ID of the exported pipeline. When the spec needs
+	// several pipelines, their IDs are suffixed with their position.
+	// Defaults to "turbine".
+	PipelineID string
+	// FunctionImageSetting is the processor setting holding the image of a
+	// function. Defaults to "image".
+	FunctionImageSetting string
+}
+
+// Export renders a spec as a Conduit pipeline configuration file.
+func Export(spec *ir.DeploymentSpec, opts ExportOptions) ([]byte, error) {
+	cfg, err := ToConfiguration(spec, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ToConfiguration converts a spec into Conduit pipelines.
+//
+// A Conduit pipeline reads from all of its sources, runs every record through
+// the same chain of processors and writes to all of its destinations. Every
+// path of the spec from a source to a destination is therefore grouped with
+// the paths going through the same functions, and each group becomes one
+// pipeline. Connectors map to pipeline connectors, with "builtin:" prepended
+// to plugin names without a plugin type, and functions map to pipeline
+// processors using a standalone processor plugin named after the function.
+func ToConfiguration(spec *ir.DeploymentSpec, opts ExportOptions) (*Configuration, error) {
+	if opts.PipelineID == "" {
+		opts.PipelineID = "turbine"
+	}
+	if opts.FunctionImageSetting == "" {
+		opts.FunctionImageSetting = "image"
+	}
+
+	if _, err := spec.BuildDAG(); err != nil {
+		return nil, err
+	}
+
+	g := newGraph(spec)
+	groups := g.pipelineGroups()
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("spec has no path from a source to a destination")
+	}
+
+	cfg := &Configuration{Version: PipelineConfigVersion}
+	for i, grp := range groups {
+		id := opts.PipelineID
+		if len(groups) > 1 {
+			id += "-" + strconv.Itoa(i+1)
+		}
+
+		p := Pipeline{
+			ID:          id,
+			Status:      StatusRunning,
+			Description: grp.description(g),
+		}
+		connectors := append(append([]string{}, grp.sources...), grp.destinations...)
+		connectorIDs := pipelineIDs(connectors, func(id string) string { return g.connectors[id].Name })
+		for _, c := range connectors {
+			p.Connectors = append(p.Connectors, connector(g.connectors[c], connectorIDs[c]))
+		}
+		processorIDs := pipelineIDs(grp.functions, func(id string) string { return g.functions[id].Name })
+		for _, fn := range grp.functions {
+			p.Processors = append(p.Processors, processor(g.functions[fn], processorIDs[fn], opts))
+		}
+		cfg.Pipelines = append(cfg.Pipelines, p)
+	}
+
+	return cfg, nil
+}
+
+func connector(c *ir.ConnectorSpec, id string) Connector {
+	plugin := c.PluginName
+	if !strings.Contains(plugin, ":") {
+		plugin = builtinPrefix + plugin
+	}
+
+	var settings map[string]string
+	if len(c.PluginConfig) > 0 {
+		settings = make(map[string]string, len(c.PluginConfig))
+		for k, v := range c.PluginConfig {
+			settings[k] = v
+		}
+	}
+
+	return Connector{
+		ID:       id,
+		Type:     string(c.PluginType),
+		Plugin:   plugin,
+		Settings: settings,
+	}
+}
+
+func processor(f *ir.FunctionSpec, id string, opts ExportOptions) Processor {
+	p := Processor{
+		ID:     id,
+		Plugin: f.Name,
+	}
+	if f.Image != "" {
+		p.Settings = map[string]string{opts.FunctionImageSetting: f.Image}
+	}
+	return p
+}
+
+func resourceID(name, uuid string) string {
+	if name != "" {
+		return name
+	}
+	return uuid
+}
+
+// pipelineIDs returns the IDs of the connectors or processors of a pipeline
+// by UUID. Resources are identified by name, unless another one of the
+// pipeline shares it, Conduit requires IDs to be unique within a pipeline:
+// their UUID is then appended to their name.
+func pipelineIDs(uuids []string, name func(uuid string) string) map[string]string {
+	count := make(map[string]int, len(uuids))
+	for _, id := range uuids {
+		count[name(id)]++
+	}
+
+	ids := make(map[string]string, len(uuids))
+	for _, id := range uuids {
+		n := name(id)
+		switch {
+		case n == "":
+			ids[id] = id
+		case count[n] > 1:
+			ids[id] = n + "-" + id
+		default:
+			ids[id] = n
+		}
+	}
+	return ids
+}
+
+// graph indexes the resources and streams of a spec by UUID.
+type graph struct {
+	connectors map[string]*ir.ConnectorSpec
+	functions  map[string]*ir.FunctionSpec
+	children   map[string][]string
+	sources    []string
+}
+
+func newGraph(spec *ir.DeploymentSpec) *graph {
+	g := &graph{
+		connectors: make(map[string]*ir.ConnectorSpec),
+		functions:  make(map[string]*ir.FunctionSpec),
+		children:   make(map[string][]string),
+	}
+	for i := range spec.Connectors {
+		c := &spec.Connectors[i]
+		g.connectors[c.UUID] = c
+		if c.PluginType == ir.PluginSource {
+			g.sources = append(g.sources, c.UUID)
+		}
+	}
+	for i := range spec.Functions {
+		g.functions[spec.Functions[i].UUID] = &spec.Functions[i]
+	}
+	for _, s := range spec.Streams {
+		g.children[s.FromUUID] = append(g.children[s.FromUUID], s.ToUUID)
+	}
+	return g
+}
+
+// group is the set of paths turned into one pipeline: every source is
+// connected to every destination through the same chain of functions.
+type group struct {
+	sources      []string
+	functions    []string
+	destinations []string
+}
+
+func (grp group) description(g *graph) string {
+	names := func(ids []string, name func(string) string) string {
+		out := make([]string, len(ids))
+		for i, id := range ids {
+			out[i] = name(id)
+		}
+		return strings.Join(out, ", ")
+	}
+	connectorName := func(id string) string { return resourceID(g.connectors[id].Name, id) }
+	functionName := func(id string) string { return resourceID(g.functions[id].Name, id) }
+
+	desc := "Reads from " + names(grp.sources, connectorName)
+	if len(grp.functions) > 0 {
+		desc += ", processes with " + names(grp.functions, functionName)
+	}
+	return desc + " and writes to " + names(grp.destinations, connectorName) + "."
+}
+
+// pipelineGroups enumerates every path from a source to a destination and
+// merges the paths into groups which can each run as a single pipeline.
+func (g *graph) pipelineGroups() []group {
+	type key struct{ source, chain string }
+
+	var (
+		keys  []key
+		paths = make(map[key]*group)
+	)
+	for _, src := range g.sources {
+		var walk func(id string, chain []string)
+		walk = func(id string, chain []string) {
+			for _, child := range g.children[id] {
+				if _, ok := g.functions[child]; ok {
+					walk(child, append(chain[:len(chain):len(chain)], child))
+					continue
+				}
+				k := key{source: src, chain: strings.Join(chain, ",")}
+				grp, ok := paths[k]
+				if !ok {
+					grp = &group{sources: []string{src}, functions: chain}
+					paths[k] = grp
+					keys = append(keys, k)
+				}
+				grp.destinations = appendUnique(grp.destinations, child)
+			}
+		}
+		walk(src, nil)
+	}
+
+	// Sources sharing the same functions and destinations share a pipeline.
+	var (
+		groups []group
+		merged = make(map[string]int)
+	)
+	for _, k := range keys {
+		grp := paths[k]
+		sort.Strings(grp.destinations)
+		mk := k.chain + "|" + strings.Join(grp.destinations, ",")
+		if i, ok := merged[mk]; ok {
+			groups[i].sources = append(groups[i].sources, grp.sources...)
+			continue
+		}
+		merged[mk] = len(groups)
+		groups = append(groups, *grp)
+	}
+	return groups
+}
+
+func appendUnique(ids []string, id string) []string {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
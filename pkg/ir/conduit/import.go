@@ -0,0 +1,283 @@
+package conduit
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+	irv3 "github.com/meroxa/turbine-core/v2/pkg/ir/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// ImportOptions set the spec fields which a pipeline configuration does not describe.
+type ImportOptions struct {
+	// Language defaults to golang.
+	Language ir.Lang
+	// TurbineVersion defaults to "0.0.0".
+	TurbineVersion string
+	GitSHA         string
+	// FunctionImageSetting is the processor setting holding the image of a
+	// function. Defaults to "image".
+	FunctionImageSetting string
+}
+
+// Unsupported is a construct of a pipeline configuration which is not
+// represented in the imported spec.
+type Unsupported struct {
+	// Path is the JSON pointer of the construct in the configuration file.
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Import reads a Conduit pipeline configuration file into a v3 spec. The
+// constructs which could not be imported are returned along with the spec.
+func Import(data []byte, opts ImportOptions) (*ir.DeploymentSpec, []Unsupported, error) {
+	var cfg Configuration
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse pipeline configuration: %w", err)
+	}
+	return FromConfiguration(&cfg, opts)
+}
+
+// FromConfiguration converts Conduit pipelines into a v3 spec.
+//
+// Every pipeline streams each of its sources through its processors, in
+// order, into each of its destinations. Processors are imported as functions
+// when they set the function image, like the ones written by Export, other
+// processors are not supported. Identical connectors and processors used by
+// several pipelines are imported once.
+func FromConfiguration(cfg *Configuration, opts ImportOptions) (*ir.DeploymentSpec, []Unsupported, error) {
+	if opts.Language == "" {
+		opts.Language = ir.GoLang
+	}
+	if opts.TurbineVersion == "" {
+		opts.TurbineVersion = "0.0.0"
+	}
+	if opts.FunctionImageSetting == "" {
+		opts.FunctionImageSetting = "image"
+	}
+
+	if major, _, _ := strings.Cut(cfg.Version, "."); major != "2" {
+		return nil, nil, fmt.Errorf("unsupported pipeline configuration version %q, supported versions: 2.x", cfg.Version)
+	}
+
+	im := &importer{
+		opts: opts,
+		spec: &ir.DeploymentSpec{
+			Definition: ir.DefinitionSpec{
+				GitSha: opts.GitSHA,
+				Metadata: ir.MetadataSpec{
+					Turbine: ir.TurbineSpec{
+						Language: opts.Language,
+						Version:  opts.TurbineVersion,
+					},
+					SpecVersion: ir.SpecVersion_v3,
+				},
+			},
+		},
+		connectors: make(map[string]Connector),
+		functions:  make(map[string]Processor),
+		streams:    make(map[string]bool),
+	}
+
+	for i, p := range cfg.Pipelines {
+		im.importPipeline(fmt.Sprintf("/pipelines/%d", i), p)
+	}
+
+	if len(im.spec.Connectors) == 0 {
+		return nil, im.unsupported, fmt.Errorf("no pipeline could be imported")
+	}
+
+	out, err := im.spec.Marshal()
+	if err != nil {
+		return nil, im.unsupported, err
+	}
+	if err := irv3.ValidateSpec(out, ir.SpecVersion_v3); err != nil {
+		return nil, im.unsupported, err
+	}
+	g, err := im.spec.BuildDAG()
+	if err != nil {
+		return nil, im.unsupported, err
+	}
+	if err := im.spec.ValidateDAG(g); err != nil {
+		return nil, im.unsupported, err
+	}
+
+	return im.spec, im.unsupported, nil
+}
+
+type importer struct {
+	opts        ImportOptions
+	spec        *ir.DeploymentSpec
+	unsupported []Unsupported
+
+	// connectors and functions hold what was imported under each name.
+	connectors map[string]Connector
+	functions  map[string]Processor
+	streams    map[string]bool
+}
+
+func (im *importer) report(path, format string, args ...interface{}) {
+	im.unsupported = append(im.unsupported, Unsupported{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (im *importer) importPipeline(path string, p Pipeline) {
+	if p.Name != "" {
+		im.report(path+"/name", "name of pipeline %q is not supported", p.ID)
+	}
+	if p.Description != "" {
+		im.report(path+"/description", "description of pipeline %q is not supported", p.ID)
+	}
+	if p.Status != "" {
+		im.report(path+"/status", "status of pipeline %q is not supported", p.ID)
+	}
+	if len(p.DLQ) > 0 {
+		im.report(path+"/dlq", "dead-letter queue of pipeline %q is not supported", p.ID)
+	}
+
+	var sources, destinations []Connector
+	for i, c := range p.Connectors {
+		cpath := fmt.Sprintf("%s/connectors/%d", path, i)
+		if len(c.Processors) > 0 {
+			im.report(cpath+"/processors", "processors of connector %q are not supported", c.ID)
+		}
+		switch c.Type {
+		case TypeSource:
+			sources = append(sources, c)
+		case TypeDestination:
+			destinations = append(destinations, c)
+		default:
+			im.report(cpath+"/type", "connector %q has unknown type %q", c.ID, c.Type)
+		}
+	}
+
+	var functions []Processor
+	for i, proc := range p.Processors {
+		ppath := fmt.Sprintf("%s/processors/%d", path, i)
+		if _, ok := proc.Settings[im.opts.FunctionImageSetting]; !ok {
+			im.report(ppath, "processor %q with plugin %q is not a function, processors are not supported", proc.ID, proc.Plugin)
+			continue
+		}
+		if proc.Condition != "" {
+			im.report(ppath+"/condition", "condition of processor %q is not supported", proc.ID)
+		}
+		if proc.Workers != 0 {
+			im.report(ppath+"/workers", "workers of processor %q are not supported", proc.ID)
+		}
+		functions = append(functions, proc)
+	}
+
+	if len(sources) == 0 || len(destinations) == 0 {
+		im.report(path, "pipeline %q needs at least one source and one destination, it was skipped", p.ID)
+		return
+	}
+
+	var (
+		sourceIDs      []string
+		destinationIDs []string
+		chain          []string
+	)
+	for _, c := range sources {
+		sourceIDs = append(sourceIDs, im.connector(p.ID, c))
+	}
+	for _, c := range destinations {
+		destinationIDs = append(destinationIDs, im.connector(p.ID, c))
+	}
+	for _, f := range functions {
+		chain = append(chain, im.function(p.ID, f))
+	}
+
+	// sources → f1 → … → fn → destinations
+	from := sourceIDs
+	for _, f := range chain {
+		for _, id := range from {
+			im.stream(id, f)
+		}
+		from = []string{f}
+	}
+	for _, to := range destinationIDs {
+		for _, id := range from {
+			im.stream(id, to)
+		}
+	}
+}
+
+// connector adds the connector to the spec, unless an identical one already
+// was, and returns its UUID. Connectors sharing an ID across pipelines are
+// named after their pipeline, see pipelineScoped.
+func (im *importer) connector(pipelineID string, c Connector) string {
+	name := c.ID
+	if existing, ok := im.connectors[name]; ok && !reflect.DeepEqual(existing, c) {
+		name = pipelineScoped(pipelineID, c.ID)
+	}
+	if _, ok := im.connectors[name]; ok {
+		for _, sc := range im.spec.Connectors {
+			if sc.Name == name {
+				return sc.UUID
+			}
+		}
+	}
+	im.connectors[name] = c
+
+	sc := ir.ConnectorSpec{
+		UUID:         uuid.New().String(),
+		Name:         name,
+		PluginType:   ir.DirectionType(c.Type),
+		PluginName:   strings.TrimPrefix(c.Plugin, builtinPrefix),
+		PluginConfig: c.Settings,
+	}
+	im.spec.Connectors = append(im.spec.Connectors, sc)
+	return sc.UUID
+}
+
+// function adds the processor to the spec as a function, unless an identical
+// one already was, and returns its UUID. Processors sharing an ID across
+// pipelines are named after their pipeline, like connectors.
+func (im *importer) function(pipelineID string, p Processor) string {
+	name := p.ID
+	if existing, ok := im.functions[name]; ok && !reflect.DeepEqual(existing, p) {
+		name = pipelineScoped(pipelineID, p.ID)
+	}
+	if _, ok := im.functions[name]; ok {
+		for _, f := range im.spec.Functions {
+			if f.Name == name {
+				return f.UUID
+			}
+		}
+	}
+	im.functions[name] = p
+
+	f := ir.FunctionSpec{
+		UUID:  uuid.New().String(),
+		Name:  name,
+		Image: p.Settings[im.opts.FunctionImageSetting],
+	}
+	im.spec.Functions = append(im.spec.Functions, f)
+	return f.UUID
+}
+
+// pipelineScoped names a connector or processor after its pipeline. Function
+// names cannot hold a ".", "-" is used instead.
+func pipelineScoped(pipelineID, id string) string {
+	return pipelineID + "-" + id
+}
+
+func (im *importer) stream(from, to string) {
+	name := from + "_" + to
+	if im.streams[name] {
+		return
+	}
+	im.streams[name] = true
+
+	im.spec.Streams = append(im.spec.Streams, ir.StreamSpec{
+		UUID:     uuid.New().String(),
+		Name:     name,
+		FromUUID: from,
+		ToUUID:   to,
+	})
+}
@@ -0,0 +1,404 @@
+package ir
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+type ChangeType string
+
+const (
+	ChangeAdded   ChangeType = "added"
+	ChangeRemoved ChangeType = "removed"
+	ChangeChanged ChangeType = "changed"
+)
+
+// Kinds of the resources found in a diff or a plan.
+const (
+	KindSource      = string(PluginSource)
+	KindDestination = string(PluginDestination)
+	KindFunction    = "function"
+	KindStream      = "stream"
+)
+
+// FieldChange is the change of a single field, or of a single plugin config key.
+type FieldChange struct {
+	Field  string     `json:"field"`
+	Change ChangeType `json:"change"`
+	Old    string     `json:"old,omitempty"`
+	New    string     `json:"new,omitempty"`
+}
+
+// ResourceDiff describes how a connector, function or stream differs between two specs.
+type ResourceDiff struct {
+	Kind   string        `json:"kind"`
+	Name   string        `json:"name"`
+	Change ChangeType    `json:"change"`
+	Fields []FieldChange `json:"fields,omitempty"`
+	// Config lists the plugin config keys which were added, removed or changed.
+	Config []FieldChange `json:"config,omitempty"`
+}
+
+// SpecDiff lists every resource which differs between two specs.
+//
+// Resources are matched by name rather than by UUID, so that a freshly recorded
+// spec can be compared with a deployed one: connectors by plugin type and name,
+// functions by name and streams by the names of the resources they connect.
+// Streams are therefore only ever added or removed. Functions are listed
+// upstream first, removed ones as they were chained in the old spec.
+//
+// Resources sharing their kind and name with another one, in either spec, are
+// told apart by their position in the graph: they are named after the
+// resources they read from, e.g. "anonymize (pg)", then numbered in order of
+// appearance, e.g. "anonymize (pg) #2".
+type SpecDiff struct {
+	Connectors []ResourceDiff `json:"connectors"`
+	Functions  []ResourceDiff `json:"functions"`
+	Streams    []ResourceDiff `json:"streams"`
+}
+
+// Empty reports whether both specs describe the same resources.
+func (d *SpecDiff) Empty() bool {
+	return len(d.Connectors) == 0 && len(d.Functions) == 0 && len(d.Streams) == 0
+}
+
+// Diff compares the spec with a newer one.
+func (d *DeploymentSpec) Diff(to *DeploymentSpec) *SpecDiff {
+	shared := sharedNames(d, to)
+	fromLabels := d.resourceLabels(shared)
+	toLabels := to.resourceLabels(shared)
+
+	return &SpecDiff{
+		Connectors: diffConnectors(d, to, fromLabels, toLabels),
+		Functions:  diffFunctions(d, to, fromLabels, toLabels),
+		Streams:    diffStreams(d, to, fromLabels, toLabels),
+	}
+}
+
+// labelledConnector is a connector along with its label, see resourceLabels.
+type labelledConnector struct {
+	ConnectorSpec
+	label string
+}
+
+func diffConnectors(from, to *DeploymentSpec, fromLabels, toLabels map[string]string) []ResourceDiff {
+	index := func(d *DeploymentSpec, labels map[string]string) map[string]labelledConnector {
+		out := make(map[string]labelledConnector, len(d.Connectors))
+		for _, c := range d.Connectors {
+			out[string(c.PluginType)+"/"+labels[c.UUID]] = labelledConnector{ConnectorSpec: c, label: labels[c.UUID]}
+		}
+		return out
+	}
+	old := index(from, fromLabels)
+	cur := index(to, toLabels)
+
+	var diffs []ResourceDiff
+	for _, k := range unionKeys(old, cur) {
+		o, inOld := old[k]
+		n, inNew := cur[k]
+		switch {
+		case !inOld:
+			diffs = append(diffs, ResourceDiff{Kind: string(n.PluginType), Name: n.label, Change: ChangeAdded})
+		case !inNew:
+			diffs = append(diffs, ResourceDiff{Kind: string(o.PluginType), Name: o.label, Change: ChangeRemoved})
+		default:
+			rd := ResourceDiff{
+				Kind:   string(n.PluginType),
+				Name:   n.label,
+				Change: ChangeChanged,
+				Fields: diffField(nil, "plugin_name", o.PluginName, n.PluginName),
+				Config: diffConfig(o.PluginConfig, n.PluginConfig),
+			}
+			if len(rd.Fields) > 0 || len(rd.Config) > 0 {
+				diffs = append(diffs, rd)
+			}
+		}
+	}
+	return diffs
+}
+
+func diffFunctions(from, to *DeploymentSpec, fromLabels, toLabels map[string]string) []ResourceDiff {
+	old := make(map[string]FunctionSpec, len(from.Functions))
+	for _, f := range from.Functions {
+		old[fromLabels[f.UUID]] = f
+	}
+	cur := make(map[string]FunctionSpec, len(to.Functions))
+	for _, f := range to.Functions {
+		cur[toLabels[f.UUID]] = f
+	}
+
+	// Removed functions are ranked by their position in the old spec, the
+	// others by their position in the new one.
+	fromOrder := from.topologicalOrder()
+	toOrder := to.topologicalOrder()
+	rank := make(map[string]int)
+
+	var diffs []ResourceDiff
+	for _, k := range unionKeys(old, cur) {
+		o, inOld := old[k]
+		n, inNew := cur[k]
+		switch {
+		case !inOld:
+			rank[k] = toOrder[n.UUID]
+			diffs = append(diffs, ResourceDiff{Kind: KindFunction, Name: k, Change: ChangeAdded})
+		case !inNew:
+			rank[k] = fromOrder[o.UUID]
+			diffs = append(diffs, ResourceDiff{Kind: KindFunction, Name: k, Change: ChangeRemoved})
+		default:
+			if fields := diffField(nil, "image", o.Image, n.Image); len(fields) > 0 {
+				rank[k] = toOrder[n.UUID]
+				diffs = append(diffs, ResourceDiff{Kind: KindFunction, Name: k, Change: ChangeChanged, Fields: fields})
+			}
+		}
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return rank[diffs[i].Name] < rank[diffs[j].Name]
+	})
+	return diffs
+}
+
+func diffStreams(from, to *DeploymentSpec, fromLabels, toLabels map[string]string) []ResourceDiff {
+	old := from.streamsByEndpoints(fromLabels)
+	cur := to.streamsByEndpoints(toLabels)
+
+	var diffs []ResourceDiff
+	for _, k := range unionKeys(old, cur) {
+		_, inOld := old[k]
+		_, inNew := cur[k]
+		switch {
+		case !inOld:
+			diffs = append(diffs, ResourceDiff{Kind: KindStream, Name: k, Change: ChangeAdded})
+		case !inNew:
+			diffs = append(diffs, ResourceDiff{Kind: KindStream, Name: k, Change: ChangeRemoved})
+		}
+	}
+	return diffs
+}
+
+// streamsByEndpoints indexes the streams by "<from label> -> <to label>".
+// Streams repeated between the same resources are numbered in order of
+// appearance.
+func (d *DeploymentSpec) streamsByEndpoints(labels map[string]string) map[string]StreamSpec {
+	name := func(id string) string {
+		if l, ok := labels[id]; ok {
+			return l
+		}
+		return id
+	}
+
+	streams := make(map[string]StreamSpec, len(d.Streams))
+	for _, s := range d.Streams {
+		key := name(s.FromUUID) + " -> " + name(s.ToUUID)
+		for n := 2; ; n++ {
+			if _, ok := streams[key]; !ok {
+				break
+			}
+			key = fmt.Sprintf("%s -> %s #%d", name(s.FromUUID), name(s.ToUUID), n)
+		}
+		streams[key] = s
+	}
+	return streams
+}
+
+// sharedNames returns the "<kind>/<name>" of the connectors and functions
+// sharing their kind and name with another resource in either spec.
+func sharedNames(specs ...*DeploymentSpec) map[string]bool {
+	shared := make(map[string]bool)
+	for _, d := range specs {
+		seen := make(map[string]bool)
+		for _, r := range d.resources() {
+			if seen[r.kindName()] {
+				shared[r.kindName()] = true
+			}
+			seen[r.kindName()] = true
+		}
+	}
+	return shared
+}
+
+// specResource is a connector or a function of a spec.
+type specResource struct {
+	uuid string
+	kind string
+	name string
+}
+
+func (r specResource) kindName() string {
+	return r.kind + "/" + r.name
+}
+
+// resources lists the connectors and functions of the spec in order.
+func (d *DeploymentSpec) resources() []specResource {
+	out := make([]specResource, 0, len(d.Connectors)+len(d.Functions))
+	for _, c := range d.Connectors {
+		out = append(out, specResource{uuid: c.UUID, kind: string(c.PluginType), name: c.Name})
+	}
+	for _, f := range d.Functions {
+		out = append(out, specResource{uuid: f.UUID, kind: KindFunction, name: f.Name})
+	}
+	return out
+}
+
+// resourceLabels names every connector and function of the spec by UUID.
+// Resources are labelled by name, unless their kind and name are shared, in
+// which case their label lists the labels of the resources they read from,
+// and resources still sharing a label are numbered in order of appearance.
+func (d *DeploymentSpec) resourceLabels(shared map[string]bool) map[string]string {
+	resources := d.resources()
+	byID := make(map[string]specResource, len(resources))
+	for _, r := range resources {
+		byID[r.uuid] = r
+	}
+	inputs := make(map[string][]string)
+	for _, s := range d.Streams {
+		inputs[s.ToUUID] = append(inputs[s.ToUUID], s.FromUUID)
+	}
+
+	positions := make(map[string]string, len(resources))
+	visiting := make(map[string]bool)
+	var position func(id string) string
+	position = func(id string) string {
+		if p, ok := positions[id]; ok {
+			return p
+		}
+		r, ok := byID[id]
+		if !ok {
+			return id
+		}
+		if !shared[r.kindName()] || visiting[id] {
+			return r.name
+		}
+
+		visiting[id] = true
+		var from []string
+		for _, in := range inputs[id] {
+			from = append(from, position(in))
+		}
+		delete(visiting, id)
+
+		p := r.name
+		if len(from) > 0 {
+			sort.Strings(from)
+			p += " (" + strings.Join(from, ", ") + ")"
+		}
+		positions[id] = p
+		return p
+	}
+
+	labels := make(map[string]string, len(resources))
+	seen := make(map[string]int)
+	for _, r := range resources {
+		p := position(r.uuid)
+		seen[r.kind+"/"+p]++
+		if n := seen[r.kind+"/"+p]; n > 1 {
+			p = fmt.Sprintf("%s #%d", p, n)
+		}
+		labels[r.uuid] = p
+	}
+	return labels
+}
+
+func diffField(changes []FieldChange, field, old, cur string) []FieldChange {
+	if old == cur {
+		return changes
+	}
+	return append(changes, FieldChange{Field: field, Change: ChangeChanged, Old: old, New: cur})
+}
+
+func diffConfig(old, cur map[string]string) []FieldChange {
+	var changes []FieldChange
+	for _, k := range unionKeys(old, cur) {
+		o, inOld := old[k]
+		n, inNew := cur[k]
+		switch {
+		case !inOld:
+			changes = append(changes, FieldChange{Field: k, Change: ChangeAdded, New: n})
+		case !inNew:
+			changes = append(changes, FieldChange{Field: k, Change: ChangeRemoved, Old: o})
+		case o != n:
+			changes = append(changes, FieldChange{Field: k, Change: ChangeChanged, Old: o, New: n})
+		}
+	}
+	return changes
+}
+
+func unionKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Operation is a single step of a deployment plan.
+type Operation struct {
+	Action Action `json:"action"`
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	// Diff holds the changes applied by update operations.
+	Diff *ResourceDiff `json:"diff,omitempty"`
+}
+
+// Plan orders the operations of a diff so they can be applied one by one:
+// streams are deleted before the resources they connect and created after them,
+// resources are deleted downstream first and created upstream first.
+func (d *SpecDiff) Plan() []Operation {
+	var (
+		deletes []Operation
+		updates []Operation
+		creates []Operation
+	)
+
+	var resources []ResourceDiff
+	resources = append(resources, d.Connectors...)
+	resources = append(resources, d.Functions...)
+	resources = append(resources, d.Streams...)
+	for _, kind := range []string{KindStream, KindDestination, KindFunction, KindSource} {
+		var ops []Operation
+		for _, rd := range resources {
+			if rd.Kind == kind && rd.Change == ChangeRemoved {
+				ops = append(ops, Operation{Action: ActionDelete, Kind: rd.Kind, Name: rd.Name})
+			}
+		}
+		if kind == KindFunction {
+			// Functions are listed upstream first, see SpecDiff.
+			slices.Reverse(ops)
+		}
+		deletes = append(deletes, ops...)
+	}
+	for _, kind := range []string{KindSource, KindFunction, KindDestination, KindStream} {
+		for _, rd := range resources {
+			if rd.Kind != kind {
+				continue
+			}
+			switch rd.Change {
+			case ChangeChanged:
+				rd := rd
+				updates = append(updates, Operation{Action: ActionUpdate, Kind: rd.Kind, Name: rd.Name, Diff: &rd})
+			case ChangeAdded:
+				creates = append(creates, Operation{Action: ActionCreate, Kind: rd.Kind, Name: rd.Name})
+			}
+		}
+	}
+
+	plan := make([]Operation, 0, len(deletes)+len(updates)+len(creates))
+	plan = append(plan, deletes...)
+	plan = append(plan, updates...)
+	return append(plan, creates...)
+}
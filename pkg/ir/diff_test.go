@@ -0,0 +1,176 @@
+package ir_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+	"github.com/stretchr/testify/assert"
+)
+
+func deployedSpec() *ir.DeploymentSpec {
+	return &ir.DeploymentSpec{
+		Connectors: []ir.ConnectorSpec{
+			{UUID: "1", Name: "pg", PluginType: ir.PluginSource, PluginName: "postgres", PluginConfig: map[string]string{"table": "users", "url": "old"}},
+			{UUID: "2", Name: "s3", PluginType: ir.PluginDestination, PluginName: "s3"},
+			{UUID: "3", Name: "archive", PluginType: ir.PluginDestination, PluginName: "file"},
+		},
+		Functions: []ir.FunctionSpec{
+			{UUID: "4", Name: "anonymize", Image: "app:1"},
+		},
+		Streams: []ir.StreamSpec{
+			{UUID: "1_4", Name: "1_4", FromUUID: "1", ToUUID: "4"},
+			{UUID: "4_2", Name: "4_2", FromUUID: "4", ToUUID: "2"},
+			{UUID: "4_3", Name: "4_3", FromUUID: "4", ToUUID: "3"},
+		},
+	}
+}
+
+func recordedSpec() *ir.DeploymentSpec {
+	return &ir.DeploymentSpec{
+		Connectors: []ir.ConnectorSpec{
+			{UUID: "a", Name: "pg", PluginType: ir.PluginSource, PluginName: "postgres", PluginConfig: map[string]string{"table": "users", "url": "new", "schema": "public"}},
+			{UUID: "b", Name: "s3", PluginType: ir.PluginDestination, PluginName: "s3"},
+			{UUID: "c", Name: "kafka", PluginType: ir.PluginDestination, PluginName: "kafka"},
+		},
+		Functions: []ir.FunctionSpec{
+			{UUID: "d", Name: "anonymize", Image: "app:2"},
+		},
+		Streams: []ir.StreamSpec{
+			{UUID: "a_d", Name: "a_d", FromUUID: "a", ToUUID: "d"},
+			{UUID: "d_b", Name: "d_b", FromUUID: "d", ToUUID: "b"},
+			{UUID: "d_c", Name: "d_c", FromUUID: "d", ToUUID: "c"},
+		},
+	}
+}
+
+func Test_Diff(t *testing.T) {
+	diff := deployedSpec().Diff(recordedSpec())
+
+	assert.Equal(t, &ir.SpecDiff{
+		Connectors: []ir.ResourceDiff{
+			{Kind: ir.KindDestination, Name: "archive", Change: ir.ChangeRemoved},
+			{Kind: ir.KindDestination, Name: "kafka", Change: ir.ChangeAdded},
+			{
+				Kind:   ir.KindSource,
+				Name:   "pg",
+				Change: ir.ChangeChanged,
+				Config: []ir.FieldChange{
+					{Field: "schema", Change: ir.ChangeAdded, New: "public"},
+					{Field: "url", Change: ir.ChangeChanged, Old: "old", New: "new"},
+				},
+			},
+		},
+		Functions: []ir.ResourceDiff{
+			{
+				Kind:   ir.KindFunction,
+				Name:   "anonymize",
+				Change: ir.ChangeChanged,
+				Fields: []ir.FieldChange{{Field: "image", Change: ir.ChangeChanged, Old: "app:1", New: "app:2"}},
+			},
+		},
+		Streams: []ir.ResourceDiff{
+			{Kind: ir.KindStream, Name: "anonymize -> archive", Change: ir.ChangeRemoved},
+			{Kind: ir.KindStream, Name: "anonymize -> kafka", Change: ir.ChangeAdded},
+		},
+	}, diff)
+	assert.False(t, diff.Empty())
+}
+
+func Test_Diff_Same(t *testing.T) {
+	assert.True(t, deployedSpec().Diff(deployedSpec()).Empty())
+}
+
+func Test_Diff_SharedFunctionName(t *testing.T) {
+	spec := func(functions int) *ir.DeploymentSpec {
+		s := &ir.DeploymentSpec{
+			Connectors: []ir.ConnectorSpec{
+				{UUID: "pg", Name: "pg", PluginType: ir.PluginSource, PluginName: "postgres"},
+				{UUID: "kafka", Name: "kafka", PluginType: ir.PluginSource, PluginName: "kafka"},
+			},
+		}
+		for i, from := range []string{"pg", "kafka", "pg"}[:functions] {
+			id := fmt.Sprintf("f%d", i+1)
+			s.Functions = append(s.Functions, ir.FunctionSpec{UUID: id, Name: "f", Image: "app"})
+			s.Streams = append(s.Streams, ir.StreamSpec{UUID: from + "_" + id, Name: from + "_" + id, FromUUID: from, ToUUID: id})
+		}
+		return s
+	}
+
+	assert.True(t, spec(2).Diff(spec(2)).Empty())
+
+	assert.Equal(t, &ir.SpecDiff{
+		Functions: []ir.ResourceDiff{
+			{Kind: ir.KindFunction, Name: "f (kafka)", Change: ir.ChangeRemoved},
+		},
+		Streams: []ir.ResourceDiff{
+			{Kind: ir.KindStream, Name: "kafka -> f (kafka)", Change: ir.ChangeRemoved},
+		},
+	}, spec(2).Diff(spec(1)))
+
+	assert.Equal(t, &ir.SpecDiff{
+		Functions: []ir.ResourceDiff{
+			{Kind: ir.KindFunction, Name: "f (pg) #2", Change: ir.ChangeAdded},
+		},
+		Streams: []ir.ResourceDiff{
+			{Kind: ir.KindStream, Name: "pg -> f (pg) #2", Change: ir.ChangeAdded},
+		},
+	}, spec(2).Diff(spec(3)))
+}
+
+func Test_Plan(t *testing.T) {
+	diff := deployedSpec().Diff(recordedSpec())
+
+	var got []ir.Operation
+	for _, op := range diff.Plan() {
+		op.Diff = nil
+		got = append(got, op)
+	}
+
+	assert.Equal(t, []ir.Operation{
+		{Action: ir.ActionDelete, Kind: ir.KindStream, Name: "anonymize -> archive"},
+		{Action: ir.ActionDelete, Kind: ir.KindDestination, Name: "archive"},
+		{Action: ir.ActionUpdate, Kind: ir.KindSource, Name: "pg"},
+		{Action: ir.ActionUpdate, Kind: ir.KindFunction, Name: "anonymize"},
+		{Action: ir.ActionCreate, Kind: ir.KindDestination, Name: "kafka"},
+		{Action: ir.ActionCreate, Kind: ir.KindStream, Name: "anonymize -> kafka"},
+	}, got)
+}
+
+func Test_Plan_ChainedFunctions(t *testing.T) {
+	direct := &ir.DeploymentSpec{
+		Connectors: []ir.ConnectorSpec{
+			{UUID: "pg", Name: "pg", PluginType: ir.PluginSource, PluginName: "postgres"},
+			{UUID: "s3", Name: "s3", PluginType: ir.PluginDestination, PluginName: "s3"},
+		},
+		Streams: []ir.StreamSpec{
+			{UUID: "pg_s3", Name: "pg_s3", FromUUID: "pg", ToUUID: "s3"},
+		},
+	}
+	// zeta runs before alpha, against the name order.
+	chained := &ir.DeploymentSpec{
+		Connectors: direct.Connectors,
+		Functions: []ir.FunctionSpec{
+			{UUID: "alpha", Name: "alpha", Image: "app"},
+			{UUID: "zeta", Name: "zeta", Image: "app"},
+		},
+		Streams: []ir.StreamSpec{
+			{UUID: "pg_zeta", Name: "pg_zeta", FromUUID: "pg", ToUUID: "zeta"},
+			{UUID: "zeta_alpha", Name: "zeta_alpha", FromUUID: "zeta", ToUUID: "alpha"},
+			{UUID: "alpha_s3", Name: "alpha_s3", FromUUID: "alpha", ToUUID: "s3"},
+		},
+	}
+
+	functions := func(plan []ir.Operation) []string {
+		var out []string
+		for _, op := range plan {
+			if op.Kind == ir.KindFunction {
+				out = append(out, string(op.Action)+" "+op.Name)
+			}
+		}
+		return out
+	}
+
+	assert.Equal(t, []string{"create zeta", "create alpha"}, functions(direct.Diff(chained).Plan()))
+	assert.Equal(t, []string{"delete alpha", "delete zeta"}, functions(chained.Diff(direct).Plan()))
+}
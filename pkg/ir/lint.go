@@ -0,0 +1,415 @@
+package ir
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/heimdalr/dag"
+)
+
+// Stable IDs of the lint rules, used as violation codes and to suppress rules.
+const (
+	LintDuplicateConnectorName  = "lint.duplicate_connector_name"
+	LintDuplicateFunctionName   = "lint.duplicate_function_name"
+	LintStreamIntoSource        = "lint.stream_into_source"
+	LintStreamFromDestination   = "lint.stream_from_destination"
+	LintDestinationWithoutInput = "lint.destination_without_input"
+	LintSourceWithoutOutput     = "lint.source_without_output"
+	LintUnconsumedFunction      = "lint.unconsumed_function"
+	LintUndeclaredSecret        = "lint.undeclared_secret"
+	LintUnusedSecret            = "lint.unused_secret"
+	LintPlaintextCredential     = "lint.plaintext_credential"
+)
+
+// LintRule is a semantic check run over a spec and its DAG.
+type LintRule struct {
+	ID          string
+	Description string
+	Severity    Severity
+
+	check func(d *DeploymentSpec, g *dag.DAG) []lintViolation
+}
+
+// lintViolation is a violation found by a lint rule, along with the UUID of
+// the offending connector or function so that it can be suppressed.
+type lintViolation struct {
+	Violation
+	uuid string
+}
+
+var lintRules = []LintRule{
+	{
+		ID:          LintDuplicateConnectorName,
+		Description: "connector names must be unique",
+		Severity:    SeverityError,
+		check:       lintDuplicateConnectorNames,
+	},
+	{
+		ID:          LintDuplicateFunctionName,
+		Description: "function names should be unique",
+		Severity:    SeverityWarning,
+		check:       lintDuplicateFunctionNames,
+	},
+	{
+		ID:          LintStreamIntoSource,
+		Description: "streams cannot write into a source connector",
+		Severity:    SeverityError,
+		check:       lintStreamsIntoSources,
+	},
+	{
+		ID:          LintStreamFromDestination,
+		Description: "streams cannot read from a destination connector",
+		Severity:    SeverityError,
+		check:       lintStreamsFromDestinations,
+	},
+	{
+		ID:          LintDestinationWithoutInput,
+		Description: "destinations should receive records from a source or a function",
+		Severity:    SeverityWarning,
+		check:       lintDestinationsWithoutInput,
+	},
+	{
+		ID:          LintSourceWithoutOutput,
+		Description: "records read by a source should be consumed",
+		Severity:    SeverityWarning,
+		check:       lintSourcesWithoutOutput,
+	},
+	{
+		ID:          LintUnconsumedFunction,
+		Description: "the output of a function should be consumed",
+		Severity:    SeverityWarning,
+		check:       lintUnconsumedFunctions,
+	},
+	{
+		ID:          LintUndeclaredSecret,
+		Description: "secrets referenced by plugin configs must be declared",
+		Severity:    SeverityError,
+		check:       lintUndeclaredSecrets,
+	},
+	{
+		ID:          LintUnusedSecret,
+		Description: "declared secrets should be referenced by a plugin config",
+		Severity:    SeverityWarning,
+		check:       lintUnusedSecrets,
+	},
+	{
+		ID:          LintPlaintextCredential,
+		Description: "credentials in plugin configs should reference a secret",
+		Severity:    SeverityWarning,
+		check:       lintPlaintextCredentials,
+	},
+}
+
+// LintRules returns every available lint rule.
+func LintRules() []LintRule {
+	rules := make([]LintRule, len(lintRules))
+	copy(rules, lintRules)
+	return rules
+}
+
+type LintOptions struct {
+	// Suppress lists the rules which are not reported. An entry is either a rule ID,
+	// suppressing the rule for the whole spec, or "<rule ID>:<resource>",
+	// suppressing it for the connectors or functions with that name, or for the
+	// one with that UUID.
+	Suppress []string
+}
+
+func (o LintOptions) suppressed(v lintViolation) bool {
+	for _, s := range o.Suppress {
+		rule, resource, scoped := strings.Cut(s, ":")
+		if rule != v.Code {
+			continue
+		}
+		if !scoped || resource == v.Resource || (v.uuid != "" && resource == v.uuid) {
+			return true
+		}
+	}
+	return false
+}
+
+// Lint runs every lint rule over the spec. Resources and streams which cannot be
+// added to the DAG are skipped, they are reported by ValidationReport.
+func (d *DeploymentSpec) Lint(opts LintOptions) *ValidationReport {
+	report := &ValidationReport{}
+
+	g, _ := d.buildDAGReport()
+	for _, rule := range lintRules {
+		for _, v := range rule.check(d, g) {
+			v.Code = rule.ID
+			v.Severity = rule.Severity
+			if opts.suppressed(v) {
+				continue
+			}
+			report.Add(v.Violation)
+		}
+	}
+
+	return report
+}
+
+func lintDuplicateConnectorNames(d *DeploymentSpec, _ *dag.DAG) []lintViolation {
+	var violations []lintViolation
+
+	seen := make(map[string]int)
+	for i, c := range d.Connectors {
+		if c.Name == "" {
+			continue
+		}
+		if first, ok := seen[c.Name]; ok {
+			violations = append(violations, lintViolation{
+				Violation: Violation{
+					Pointer:  fmt.Sprintf("/connectors/%d/name", i),
+					Resource: c.Name,
+					Message:  fmt.Sprintf("connector name %q is already used by /connectors/%d", c.Name, first),
+				},
+				uuid: c.UUID,
+			})
+			continue
+		}
+		seen[c.Name] = i
+	}
+
+	return violations
+}
+
+func lintDuplicateFunctionNames(d *DeploymentSpec, _ *dag.DAG) []lintViolation {
+	var violations []lintViolation
+
+	seen := make(map[string]int)
+	for i, f := range d.Functions {
+		if f.Name == "" {
+			continue
+		}
+		if first, ok := seen[f.Name]; ok {
+			violations = append(violations, lintViolation{
+				Violation: Violation{
+					Pointer:  fmt.Sprintf("/functions/%d/name", i),
+					Resource: f.Name,
+					Message:  fmt.Sprintf("function name %q is already used by /functions/%d", f.Name, first),
+				},
+				uuid: f.UUID,
+			})
+			continue
+		}
+		seen[f.Name] = i
+	}
+
+	return violations
+}
+
+func lintStreamsIntoSources(d *DeploymentSpec, g *dag.DAG) []lintViolation {
+	var violations []lintViolation
+
+	for i, s := range d.Streams {
+		if !isEdge(g, s.FromUUID, s.ToUUID) {
+			continue
+		}
+		if c := d.connector(s.ToUUID); c != nil && c.PluginType == PluginSource {
+			violations = append(violations, lintViolation{
+				Violation: Violation{
+					Pointer:  fmt.Sprintf("/streams/%d/to_uuid", i),
+					Resource: c.Name,
+					Message:  fmt.Sprintf("stream %q writes into source %q", s.Name, c.Name),
+				},
+				uuid: c.UUID,
+			})
+		}
+	}
+
+	return violations
+}
+
+func lintStreamsFromDestinations(d *DeploymentSpec, g *dag.DAG) []lintViolation {
+	var violations []lintViolation
+
+	for i, s := range d.Streams {
+		if !isEdge(g, s.FromUUID, s.ToUUID) {
+			continue
+		}
+		if c := d.connector(s.FromUUID); c != nil && c.PluginType == PluginDestination {
+			violations = append(violations, lintViolation{
+				Violation: Violation{
+					Pointer:  fmt.Sprintf("/streams/%d/from_uuid", i),
+					Resource: c.Name,
+					Message:  fmt.Sprintf("stream %q reads from destination %q", s.Name, c.Name),
+				},
+				uuid: c.UUID,
+			})
+		}
+	}
+
+	return violations
+}
+
+func lintDestinationsWithoutInput(d *DeploymentSpec, g *dag.DAG) []lintViolation {
+	var violations []lintViolation
+
+	for i, c := range d.Connectors {
+		if c.PluginType != PluginDestination || hasParents(g, c.UUID) {
+			continue
+		}
+		violations = append(violations, lintViolation{
+			Violation: Violation{
+				Pointer:  fmt.Sprintf("/connectors/%d", i),
+				Resource: c.Name,
+				Message:  fmt.Sprintf("no stream writes to destination %q", c.Name),
+			},
+			uuid: c.UUID,
+		})
+	}
+
+	return violations
+}
+
+func lintSourcesWithoutOutput(d *DeploymentSpec, g *dag.DAG) []lintViolation {
+	var violations []lintViolation
+
+	for i, c := range d.Connectors {
+		if c.PluginType != PluginSource || hasChildren(g, c.UUID) {
+			continue
+		}
+		violations = append(violations, lintViolation{
+			Violation: Violation{
+				Pointer:  fmt.Sprintf("/connectors/%d", i),
+				Resource: c.Name,
+				Message:  fmt.Sprintf("records read by source %q are never consumed", c.Name),
+			},
+			uuid: c.UUID,
+		})
+	}
+
+	return violations
+}
+
+func lintUnconsumedFunctions(d *DeploymentSpec, g *dag.DAG) []lintViolation {
+	var violations []lintViolation
+
+	for i, f := range d.Functions {
+		if hasChildren(g, f.UUID) {
+			continue
+		}
+		violations = append(violations, lintViolation{
+			Violation: Violation{
+				Pointer:  fmt.Sprintf("/functions/%d", i),
+				Resource: f.Name,
+				Message:  fmt.Sprintf("output of function %q is never consumed", f.Name),
+			},
+			uuid: f.UUID,
+		})
+	}
+
+	return violations
+}
+
+func lintUndeclaredSecrets(d *DeploymentSpec, _ *dag.DAG) []lintViolation {
+	var violations []lintViolation
+
+	for i, c := range d.Connectors {
+		for _, k := range sortedKeys(c.PluginConfig) {
+			for _, name := range SecretRefs(c.PluginConfig[k]) {
+				if _, ok := d.Secrets[name]; ok {
+					continue
+				}
+				violations = append(violations, lintViolation{
+					Violation: Violation{
+						Pointer:  fmt.Sprintf("/connectors/%d/plugin_config/%s", i, pointerToken(k)),
+						Resource: c.Name,
+						Message:  fmt.Sprintf("connector %q references undeclared secret %q", c.Name, name),
+					},
+					uuid: c.UUID,
+				})
+			}
+		}
+	}
+
+	return violations
+}
+
+func lintUnusedSecrets(d *DeploymentSpec, _ *dag.DAG) []lintViolation {
+	used := make(map[string]bool)
+	for _, c := range d.Connectors {
+		for _, v := range c.PluginConfig {
+			for _, name := range SecretRefs(v) {
+				used[name] = true
+			}
+		}
+	}
+
+	var violations []lintViolation
+	for _, name := range sortedKeys(d.Secrets) {
+		if used[name] {
+			continue
+		}
+		violations = append(violations, lintViolation{
+			Violation: Violation{
+				Pointer: "/secrets/" + name,
+				Message: fmt.Sprintf("secret %q is not referenced by any connector", name),
+			},
+		})
+	}
+
+	return violations
+}
+
+func lintPlaintextCredentials(d *DeploymentSpec, _ *dag.DAG) []lintViolation {
+	var violations []lintViolation
+
+	for i, c := range d.Connectors {
+		for _, k := range sortedKeys(c.PluginConfig) {
+			v := c.PluginConfig[k]
+			if v == "" || !sensitiveConfigKey(k) || len(SecretRefs(v)) > 0 {
+				continue
+			}
+			violations = append(violations, lintViolation{
+				Violation: Violation{
+					Pointer:  fmt.Sprintf("/connectors/%d/plugin_config/%s", i, pointerToken(k)),
+					Resource: c.Name,
+					Message:  fmt.Sprintf("config %q of connector %q holds a plaintext credential, reference a secret instead", k, c.Name),
+				},
+				uuid: c.UUID,
+			})
+		}
+	}
+
+	return violations
+}
+
+// pointerToken escapes a key as a JSON pointer reference token, see RFC 6901.
+func pointerToken(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (d *DeploymentSpec) connector(id string) *ConnectorSpec {
+	for i := range d.Connectors {
+		if d.Connectors[i].UUID == id {
+			return &d.Connectors[i]
+		}
+	}
+	return nil
+}
+
+func isEdge(g *dag.DAG, from, to string) bool {
+	ok, err := g.IsEdge(from, to)
+	return err == nil && ok
+}
+
+func hasParents(g *dag.DAG, id string) bool {
+	parents, err := g.GetParents(id)
+	return err == nil && len(parents) > 0
+}
+
+func hasChildren(g *dag.DAG, id string) bool {
+	children, err := g.GetChildren(id)
+	return err == nil && len(children) > 0
+}
@@ -0,0 +1,148 @@
+package ir_test
+
+import (
+	"testing"
+
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+	"github.com/stretchr/testify/assert"
+)
+
+func lintSpec() *ir.DeploymentSpec {
+	return &ir.DeploymentSpec{
+		Definition: ir.DefinitionSpec{
+			Metadata: ir.MetadataSpec{SpecVersion: ir.LatestSpecVersion},
+		},
+		Connectors: []ir.ConnectorSpec{
+			{UUID: "1", Name: "pg", PluginType: ir.PluginSource},
+			{UUID: "2", Name: "pg", PluginType: ir.PluginSource},
+			{UUID: "3", Name: "s3", PluginType: ir.PluginDestination},
+			{UUID: "4", Name: "archive", PluginType: ir.PluginDestination},
+		},
+		Functions: []ir.FunctionSpec{
+			{UUID: "5", Name: "anonymize"},
+			{UUID: "6", Name: "anonymize"},
+		},
+		Streams: []ir.StreamSpec{
+			{UUID: "1_5", Name: "pg_anonymize", FromUUID: "1", ToUUID: "5"},
+			{UUID: "5_3", Name: "anonymize_s3", FromUUID: "5", ToUUID: "3"},
+			{UUID: "3_2", Name: "s3_pg", FromUUID: "3", ToUUID: "2"},
+			{UUID: "1_6", Name: "pg_anonymize2", FromUUID: "1", ToUUID: "6"},
+		},
+	}
+}
+
+func Test_Lint(t *testing.T) {
+	report := lintSpec().Lint(ir.LintOptions{})
+
+	assert.Equal(t, []ir.Violation{
+		{
+			Pointer:  "/connectors/1/name",
+			Resource: "pg",
+			Severity: ir.SeverityError,
+			Code:     ir.LintDuplicateConnectorName,
+			Message:  "connector name \"pg\" is already used by /connectors/0",
+		},
+		{
+			Pointer:  "/functions/1/name",
+			Resource: "anonymize",
+			Severity: ir.SeverityWarning,
+			Code:     ir.LintDuplicateFunctionName,
+			Message:  "function name \"anonymize\" is already used by /functions/0",
+		},
+		{
+			Pointer:  "/streams/2/to_uuid",
+			Resource: "pg",
+			Severity: ir.SeverityError,
+			Code:     ir.LintStreamIntoSource,
+			Message:  "stream \"s3_pg\" writes into source \"pg\"",
+		},
+		{
+			Pointer:  "/streams/2/from_uuid",
+			Resource: "s3",
+			Severity: ir.SeverityError,
+			Code:     ir.LintStreamFromDestination,
+			Message:  "stream \"s3_pg\" reads from destination \"s3\"",
+		},
+		{
+			Pointer:  "/connectors/3",
+			Resource: "archive",
+			Severity: ir.SeverityWarning,
+			Code:     ir.LintDestinationWithoutInput,
+			Message:  "no stream writes to destination \"archive\"",
+		},
+		{
+			Pointer:  "/connectors/1",
+			Resource: "pg",
+			Severity: ir.SeverityWarning,
+			Code:     ir.LintSourceWithoutOutput,
+			Message:  "records read by source \"pg\" are never consumed",
+		},
+		{
+			Pointer:  "/functions/1",
+			Resource: "anonymize",
+			Severity: ir.SeverityWarning,
+			Code:     ir.LintUnconsumedFunction,
+			Message:  "output of function \"anonymize\" is never consumed",
+		},
+	}, report.Violations)
+}
+
+func Test_Lint_Suppress(t *testing.T) {
+	report := lintSpec().Lint(ir.LintOptions{
+		Suppress: []string{
+			ir.LintDuplicateConnectorName,
+			ir.LintDuplicateFunctionName,
+			ir.LintStreamIntoSource,
+			ir.LintStreamFromDestination,
+			ir.LintSourceWithoutOutput,
+			ir.LintUnconsumedFunction,
+			ir.LintDestinationWithoutInput + ":s3",
+		},
+	})
+
+	codes := make([]string, 0, len(report.Violations))
+	for _, v := range report.Violations {
+		codes = append(codes, v.Code+":"+v.Resource)
+	}
+	assert.Equal(t, []string{ir.LintDestinationWithoutInput + ":archive"}, codes)
+
+	report = lintSpec().Lint(ir.LintOptions{
+		Suppress: []string{
+			ir.LintDuplicateConnectorName,
+			ir.LintDuplicateFunctionName,
+			ir.LintStreamIntoSource,
+			ir.LintStreamFromDestination,
+			ir.LintSourceWithoutOutput,
+			ir.LintUnconsumedFunction,
+			ir.LintDestinationWithoutInput + ":archive",
+		},
+	})
+	assert.Empty(t, report.Violations)
+}
+
+func Test_Lint_SuppressByUUID(t *testing.T) {
+	pointers := func(suppress string) []string {
+		report := lintSpec().Lint(ir.LintOptions{Suppress: []string{suppress}})
+
+		var out []string
+		for _, v := range report.Violations {
+			if v.Code == ir.LintDuplicateConnectorName {
+				out = append(out, v.Pointer)
+			}
+		}
+		return out
+	}
+
+	// Both connectors are named pg, only the second one is a duplicate.
+	assert.Equal(t, []string{"/connectors/1/name"}, pointers(ir.LintDuplicateConnectorName+":1"))
+	assert.Empty(t, pointers(ir.LintDuplicateConnectorName+":2"))
+}
+
+func Test_LintRules(t *testing.T) {
+	ids := map[string]bool{}
+	for _, r := range ir.LintRules() {
+		assert.NotEmpty(t, r.Description)
+		assert.False(t, ids[r.ID], "duplicate rule %s", r.ID)
+		ids[r.ID] = true
+	}
+}
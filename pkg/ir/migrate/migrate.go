@@ -0,0 +1,180 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+)
+
+// Step upgrades a marshalled spec from one spec version to the next one.
+type Step struct {
+	From    string
+	To      string
+	Migrate func(spec []byte) ([]byte, []Change, error)
+}
+
+// Validator checks that a marshalled spec is valid for a given spec version.
+type Validator func(spec []byte) error
+
+// Change describes a single modification made to a spec during a migration.
+type Change struct {
+	// Path is the JSON pointer of the modified field in the migrated spec.
+	Path        string `json:"path"`
+	Description string `json:"description"`
+}
+
+// StepReport lists the changes made by one migration step.
+type StepReport struct {
+	From    string   `json:"from"`
+	To      string   `json:"to"`
+	Changes []Change `json:"changes"`
+}
+
+// Report lists every step applied to upgrade a spec.
+type Report struct {
+	From  string       `json:"from"`
+	To    string       `json:"to"`
+	Steps []StepReport `json:"steps"`
+}
+
+// Registry holds the migration steps and validators used to upgrade specs.
+type Registry struct {
+	steps      map[string]Step
+	validators map[string]Validator
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		steps:      make(map[string]Step),
+		validators: make(map[string]Validator),
+	}
+}
+
+// Register adds a migration step. Only one step can upgrade from a given version.
+func (r *Registry) Register(s Step) error {
+	if s.From == "" || s.To == "" {
+		return fmt.Errorf("migration step requires both a source and a target spec version")
+	}
+	if s.Migrate == nil {
+		return fmt.Errorf("migration step from %q to %q has no migrate function", s.From, s.To)
+	}
+	if existing, ok := r.steps[s.From]; ok {
+		return fmt.Errorf("migration from spec version %q is already registered (to %q)", s.From, existing.To)
+	}
+
+	r.steps[s.From] = s
+	return nil
+}
+
+// RegisterValidator sets the validator run against specs of the given version,
+// both before they are migrated and after a step produced them.
+func (r *Registry) RegisterValidator(version string, v Validator) {
+	r.validators[version] = v
+}
+
+// Path returns the ordered steps needed to upgrade a spec from one version to another.
+func (r *Registry) Path(from, to string) ([]Step, error) {
+	var (
+		path    []Step
+		visited = map[string]bool{}
+	)
+
+	for v := from; v != to; {
+		if visited[v] {
+			return nil, fmt.Errorf("migration from spec version %q loops back to %q", from, v)
+		}
+		visited[v] = true
+
+		s, ok := r.steps[v]
+		if !ok {
+			return nil, fmt.Errorf("unsupported upgrade from spec version %q to %q", from, to)
+		}
+		path = append(path, s)
+		v = s.To
+	}
+
+	return path, nil
+}
+
+// Upgrade migrates a marshalled spec to the given spec version, validating the
+// input and the output of every step along the way.
+func (r *Registry) Upgrade(spec []byte, to string) ([]byte, *Report, error) {
+	from, err := SpecVersion(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path, err := r.Path(from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := r.validate(from, spec); err != nil {
+		return nil, nil, err
+	}
+
+	report := &Report{
+		From:  from,
+		To:    to,
+		Steps: []StepReport{},
+	}
+
+	for _, s := range path {
+		out, changes, err := s.Migrate(spec)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to migrate spec from %q to %q: %w", s.From, s.To, err)
+		}
+
+		if err := r.validate(s.To, out); err != nil {
+			return nil, nil, fmt.Errorf("spec migrated from %q to %q is invalid: %w", s.From, s.To, err)
+		}
+
+		if changes == nil {
+			changes = []Change{}
+		}
+		report.Steps = append(report.Steps, StepReport{
+			From:    s.From,
+			To:      s.To,
+			Changes: changes,
+		})
+		spec = out
+	}
+
+	return spec, report, nil
+}
+
+func (r *Registry) validate(version string, spec []byte) error {
+	v, ok := r.validators[version]
+	if !ok {
+		return nil
+	}
+	return v(spec)
+}
+
+// SpecVersion returns the spec version declared in a marshalled spec.
+func SpecVersion(spec []byte) (string, error) {
+	var s struct {
+		Definition struct {
+			Metadata struct {
+				SpecVersion string `json:"spec_version"`
+			} `json:"metadata"`
+		} `json:"definition"`
+	}
+
+	if err := json.Unmarshal(spec, &s); err != nil {
+		return "", err
+	}
+
+	if s.Definition.Metadata.SpecVersion == "" {
+		return "", fmt.Errorf("cannot upgrade spec, spec version is not specified")
+	}
+
+	return s.Definition.Metadata.SpecVersion, nil
+}
+
+// Upgrade migrates a marshalled spec of any known version to v4, the newest
+// spec version.
+func Upgrade(spec []byte) ([]byte, *Report, error) {
+	return Default().Upgrade(spec, ir.SpecVersion_v4)
+}
@@ -0,0 +1,270 @@
+package migrate_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+	"github.com/meroxa/turbine-core/v2/pkg/ir/migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readSpec(t *testing.T, version string) []byte {
+	t.Helper()
+
+	b, err := os.ReadFile(path.Join("..", "v1", "spectest", version, "spec.json"))
+	require.NoError(t, err)
+	return b
+}
+
+func Test_Upgrade(t *testing.T) {
+	testCases := []struct {
+		desc      string
+		spec      func(*testing.T) []byte
+		wantSteps []string
+		wantErr   string
+	}{
+		{
+			desc: "from 0.1.1",
+			spec: func(t *testing.T) []byte {
+				return readSpec(t, "0.1.1")
+			},
+			wantSteps: []string{"0.1.1", "0.2.0", "v3"},
+		},
+		{
+			desc: "from 0.2.0",
+			spec: func(t *testing.T) []byte {
+				return readSpec(t, "0.2.0")
+			},
+			wantSteps: []string{"0.2.0", "v3"},
+		},
+		{
+			desc: "from v3",
+			spec: func(_ *testing.T) []byte {
+				return []byte(`{
+					"connectors": [
+						{
+							"uuid": "252bc5e1-666e-4985-a12a-42af81a5d2ab",
+							"name": "pg",
+							"plugin_type": "source",
+							"plugin_name": "postgres"
+						},
+						{
+							"uuid": "dde3bf4e-0848-4579-b05d-7e6dcfae61ea",
+							"name": "s3",
+							"plugin_type": "destination",
+							"plugin_name": "s3"
+						}
+					],
+					"streams": [
+						{
+							"uuid": "",
+							"name": "pg_s3",
+							"from_uuid": "252bc5e1-666e-4985-a12a-42af81a5d2ab",
+							"to_uuid": "dde3bf4e-0848-4579-b05d-7e6dcfae61ea"
+						}
+					],
+					"definition": {
+						"git_sha": "3630e05a",
+						"metadata": {
+							"turbine": {
+								"language": "golang",
+								"version": "0.1.0"
+							},
+							"spec_version": "v3"
+						}
+					}
+				}`)
+			},
+			wantSteps: []string{"v3"},
+		},
+		{
+			desc: "without spec version",
+			spec: func(t *testing.T) []byte {
+				return readSpec(t, "empty")
+			},
+			wantErr: "cannot upgrade spec, spec version is not specified",
+		},
+		{
+			desc: "from unknown spec version",
+			spec: func(t *testing.T) []byte {
+				return readSpec(t, "0.0.0")
+			},
+			wantErr: "unsupported upgrade from spec version \"0.0.0\" to \"v4\"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, report, err := migrate.Upgrade(tc.spec(t))
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			require.Len(t, report.Steps, len(tc.wantSteps))
+			for i, from := range tc.wantSteps {
+				assert.Equal(t, from, report.Steps[i].From)
+				assert.NotEmpty(t, report.Steps[i].Changes)
+			}
+			assert.Equal(t, ir.SpecVersion_v4, report.To)
+
+			spec, err := ir.Unmarshal(got)
+			require.NoError(t, err)
+			assert.Equal(t, ir.SpecVersion_v4, spec.Definition.Metadata.SpecVersion)
+			for _, c := range spec.Connectors {
+				assert.NotEmpty(t, c.UUID)
+				assert.NotEmpty(t, c.Name)
+			}
+			for _, s := range spec.Streams {
+				assert.NotEmpty(t, s.UUID)
+				assert.NotEmpty(t, s.Name)
+			}
+		})
+	}
+}
+
+func Test_Upgrade_FromV011(t *testing.T) {
+	got, report, err := migrate.Upgrade(readSpec(t, "0.1.1"))
+	require.NoError(t, err)
+
+	spec, err := ir.Unmarshal(got)
+	require.NoError(t, err)
+
+	// source → function → destination
+	require.Len(t, spec.Streams, 2)
+	assert.Equal(t, spec.Connectors[0].UUID, spec.Streams[0].FromUUID)
+	assert.Equal(t, spec.Functions[0].UUID, spec.Streams[0].ToUUID)
+	assert.Equal(t, spec.Functions[0].UUID, spec.Streams[1].FromUUID)
+	assert.Equal(t, spec.Connectors[1].UUID, spec.Streams[1].ToUUID)
+
+	assert.Equal(t, ir.ConnectorSpec{
+		UUID:       spec.Connectors[0].UUID,
+		Name:       "mypg_user_activity",
+		PluginType: ir.PluginSource,
+		PluginName: "mypg",
+		PluginConfig: map[string]string{
+			"collection":          "user_activity",
+			"logical_replication": "true",
+		},
+	}, spec.Connectors[0])
+
+	// The secret is declared, its plaintext value is dropped.
+	assert.Equal(t, map[string]ir.SecretSpec{"key": {}}, spec.Secrets)
+	assert.NotContains(t, string(got), "valuesecret")
+	assert.Contains(t, report.Steps[1].Changes, migrate.Change{
+		Path:        "/secrets/key",
+		Description: "declared secret \"key\" without its value, reference it as ${secret:key}",
+	})
+
+	b, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"from":"0.1.1","to":"v4"`)
+}
+
+func Test_Upgrade_FromV3_Secrets(t *testing.T) {
+	spec := []byte(`{
+		"secrets": {"key": "valuesecret", "API_KEY": {"description": "key of the API"}},
+		"connectors": [],
+		"definition": {"git_sha": "", "metadata": {"turbine": {"language": "golang", "version": "1"}, "spec_version": "v3"}}
+	}`)
+
+	got, report, err := migrate.Upgrade(spec)
+	require.NoError(t, err)
+
+	upgraded, err := ir.Unmarshal(got)
+	require.NoError(t, err)
+	assert.Equal(t, ir.SpecVersion_v4, upgraded.Definition.Metadata.SpecVersion)
+
+	// The plaintext secret is declared without its value.
+	assert.Equal(t, map[string]ir.SecretSpec{
+		"key":     {},
+		"API_KEY": {Description: "key of the API"},
+	}, upgraded.Secrets)
+	assert.NotContains(t, string(got), "valuesecret")
+	assert.Contains(t, report.Steps[0].Changes, migrate.Change{
+		Path:        "/secrets/key",
+		Description: "declared secret \"key\" without its value, reference it as ${secret:key}",
+	})
+}
+
+func Test_Upgrade_FromV3_ChangePaths(t *testing.T) {
+	// The stream without a UUID comes first, but the canonical order of the
+	// migrated spec puts it second.
+	spec := []byte(`{
+		"connectors": [
+			{"uuid": "252bc5e1-666e-4985-a12a-42af81a5d2ab", "name": "pg", "plugin_type": "source", "plugin_name": "postgres"},
+			{"uuid": "dde3bf4e-0848-4579-b05d-7e6dcfae61ea", "name": "s3", "plugin_type": "destination", "plugin_name": "s3"}
+		],
+		"functions": [{"uuid": "8b2a4e43-8c6d-4f4f-9f0e-6a3c2b8d1e7f", "name": "enrich", "image": "enrich:latest"}],
+		"streams": [
+			{"uuid": "", "name": "fn_dst", "from_uuid": "8b2a4e43-8c6d-4f4f-9f0e-6a3c2b8d1e7f", "to_uuid": "dde3bf4e-0848-4579-b05d-7e6dcfae61ea"},
+			{"uuid": "4f1d2c3b-5a6e-4d7c-8b9a-0e1f2a3b4c5d", "name": "src_fn", "from_uuid": "252bc5e1-666e-4985-a12a-42af81a5d2ab", "to_uuid": "8b2a4e43-8c6d-4f4f-9f0e-6a3c2b8d1e7f"}
+		],
+		"definition": {"git_sha": "", "metadata": {"turbine": {"language": "golang", "version": "1"}, "spec_version": "v3"}}
+	}`)
+
+	got, report, err := migrate.Upgrade(spec)
+	require.NoError(t, err)
+
+	upgraded, err := ir.Unmarshal(got)
+	require.NoError(t, err)
+	require.Equal(t, "4f1d2c3b-5a6e-4d7c-8b9a-0e1f2a3b4c5d", upgraded.Streams[0].UUID)
+	require.Equal(t, "fn_dst", upgraded.Streams[1].Name)
+
+	assert.Equal(t, []migrate.Change{
+		{
+			Path:        "/streams/1/uuid",
+			Description: "assigned uuid to stream from 8b2a4e43-8c6d-4f4f-9f0e-6a3c2b8d1e7f to dde3bf4e-0848-4579-b05d-7e6dcfae61ea",
+		},
+		{
+			Path:        "/definition/metadata/spec_version",
+			Description: "changed spec version from \"v3\" to \"v4\"",
+		},
+	}, report.Steps[0].Changes)
+}
+
+func Test_Upgrade_Latest(t *testing.T) {
+	spec := []byte(`{"connectors": [], "definition": {"git_sha": "", "metadata": {"turbine": {"language": "golang", "version": "1"}, "spec_version": "v4"}}}`)
+
+	got, report, err := migrate.Upgrade(spec)
+	require.NoError(t, err)
+	assert.Equal(t, spec, got)
+	assert.Empty(t, report.Steps)
+}
+
+func Test_Registry(t *testing.T) {
+	r := migrate.NewRegistry()
+
+	step := migrate.Step{
+		From: "a",
+		To:   "b",
+		Migrate: func(_ []byte) ([]byte, []migrate.Change, error) {
+			return []byte(`{"definition": {"metadata": {"spec_version": "b"}}}`), nil, nil
+		},
+	}
+	require.NoError(t, r.Register(step))
+	require.EqualError(t, r.Register(step), "migration from spec version \"a\" is already registered (to \"b\")")
+	require.EqualError(t, r.Register(migrate.Step{From: "b", To: "c"}), "migration step from \"b\" to \"c\" has no migrate function")
+
+	spec := []byte(`{"definition": {"metadata": {"spec_version": "a"}}}`)
+
+	got, report, err := r.Upgrade(spec, "b")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"definition": {"metadata": {"spec_version": "b"}}}`, string(got))
+	assert.Equal(t, []migrate.StepReport{{From: "a", To: "b", Changes: []migrate.Change{}}}, report.Steps)
+
+	r.RegisterValidator("b", func(_ []byte) error {
+		return errors.New("boom")
+	})
+	_, _, err = r.Upgrade(spec, "b")
+	require.EqualError(t, err, "spec migrated from \"a\" to \"b\" is invalid: boom")
+
+	_, _, err = r.Upgrade(spec, "c")
+	require.EqualError(t, err, "unsupported upgrade from spec version \"a\" to \"c\"")
+}
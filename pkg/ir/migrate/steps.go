@@ -0,0 +1,384 @@
+package migrate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+	irv1 "github.com/meroxa/turbine-core/v2/pkg/ir/v1"
+	irv3 "github.com/meroxa/turbine-core/v2/pkg/ir/v3"
+	irv4 "github.com/meroxa/turbine-core/v2/pkg/ir/v4"
+)
+
+// Default returns a registry with every builtin migration step:
+// 0.1.1 → 0.2.0 → v3 → v4.
+func Default() *Registry {
+	r := NewRegistry()
+
+	for _, s := range []Step{
+		{From: irv1.SpecVersion_0_1_1, To: irv1.SpecVersion_0_2_0, Migrate: upgradeV011ToV020},
+		{From: irv1.SpecVersion_0_2_0, To: ir.SpecVersion_v3, Migrate: upgradeV020ToV3},
+		{From: ir.SpecVersion_v3, To: ir.SpecVersion_v4, Migrate: upgradeV3ToV4},
+	} {
+		if err := r.Register(s); err != nil {
+			panic(err)
+		}
+	}
+
+	r.RegisterValidator(irv1.SpecVersion_0_1_1, validateV1(irv1.SpecVersion_0_1_1))
+	r.RegisterValidator(irv1.SpecVersion_0_2_0, validateV1(irv1.SpecVersion_0_2_0))
+	r.RegisterValidator(ir.SpecVersion_v3, validateV3)
+	r.RegisterValidator(ir.SpecVersion_v4, validateV4)
+
+	return r
+}
+
+func validateV1(version string) Validator {
+	return func(spec []byte) error {
+		return irv1.ValidateSpec(spec, version)
+	}
+}
+
+func validateV3(spec []byte) error {
+	if err := irv3.ValidateSpec(spec, ir.SpecVersion_v3); err != nil {
+		return err
+	}
+	return buildDAG(spec)
+}
+
+func validateV4(spec []byte) error {
+	if err := irv4.ValidateSpec(spec, ir.SpecVersion_v4); err != nil {
+		return err
+	}
+	return buildDAG(spec)
+}
+
+func buildDAG(spec []byte) error {
+	// The plaintext secrets of v3 specs are declared by upgradeV3ToV4.
+	spec, _, err := ir.DeclarePlaintextSecrets(spec)
+	if err != nil {
+		return err
+	}
+	s, err := ir.Unmarshal(spec)
+	if err != nil {
+		return err
+	}
+	_, err = s.BuildDAG()
+	return err
+}
+
+// upgradeV011ToV020 assigns UUIDs to every resource and creates the streams
+// implied by the single source topology of 0.1.1.
+func upgradeV011ToV020(spec []byte) ([]byte, []Change, error) {
+	s, err := irv1.Unmarshal(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var changes []Change
+	for i, c := range s.Connectors {
+		if c.UUID == "" {
+			changes = append(changes, Change{
+				Path:        fmt.Sprintf("/connectors/%d/uuid", i),
+				Description: fmt.Sprintf("assigned uuid to %s connector %q", c.Type, c.Resource),
+			})
+		}
+	}
+	for i, f := range s.Functions {
+		if f.UUID == "" {
+			changes = append(changes, Change{
+				Path:        fmt.Sprintf("/functions/%d/uuid", i),
+				Description: fmt.Sprintf("assigned uuid to function %q", f.Name),
+			})
+		}
+	}
+
+	streams := len(s.Streams)
+	if _, err := s.BuildDAG(); err != nil {
+		return nil, nil, err
+	}
+
+	for i := streams; i < len(s.Streams); i++ {
+		st := &s.Streams[i]
+		st.Name = st.FromUUID + "_" + st.ToUUID
+		changes = append(changes, Change{
+			Path:        fmt.Sprintf("/streams/%d", i),
+			Description: fmt.Sprintf("created stream from %s to %s", st.FromUUID, st.ToUUID),
+		})
+	}
+
+	s.Definition.Metadata.SpecVersion = irv1.SpecVersion_0_2_0
+	changes = append(changes, specVersionChange(irv1.SpecVersion_0_1_1, irv1.SpecVersion_0_2_0))
+
+	out, err := s.Marshal()
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, changes, nil
+}
+
+// upgradeV020ToV3 converts resource based connectors into plugin based connectors.
+// Secrets are declared by name, their plaintext values are dropped.
+func upgradeV020ToV3(spec []byte) ([]byte, []Change, error) {
+	old, err := irv1.Unmarshal(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s := &ir.DeploymentSpec{
+		Definition: ir.DefinitionSpec{
+			GitSha: old.Definition.GitSha,
+			Metadata: ir.MetadataSpec{
+				Turbine: ir.TurbineSpec{
+					Language: ir.Lang(old.Definition.Metadata.Turbine.Language),
+					Version:  old.Definition.Metadata.Turbine.Version,
+				},
+				SpecVersion: ir.SpecVersion_v3,
+			},
+		},
+	}
+
+	var entries []entryChange
+	for _, c := range old.Connectors {
+		config, err := pluginConfig(c.Config)
+		if err != nil {
+			return nil, nil, fmt.Errorf("connector %q: %w", c.Resource, err)
+		}
+
+		name := c.Resource
+		if c.Collection != "" {
+			name = c.Resource + "_" + c.Collection
+			config["collection"] = c.Collection
+			entries = append(entries, entryChange{
+				list:        "connectors",
+				uuid:        c.UUID,
+				field:       "plugin_config/collection",
+				description: fmt.Sprintf("moved collection %q into the plugin config", c.Collection),
+			})
+		}
+
+		s.Connectors = append(s.Connectors, ir.ConnectorSpec{
+			UUID:         c.UUID,
+			Name:         name,
+			PluginType:   ir.DirectionType(c.Type),
+			PluginName:   c.Resource,
+			PluginConfig: config,
+		})
+		entries = append(entries,
+			entryChange{
+				list:        "connectors",
+				uuid:        c.UUID,
+				field:       "name",
+				description: fmt.Sprintf("named %s connector %q", c.Type, name),
+			},
+			entryChange{
+				list:        "connectors",
+				uuid:        c.UUID,
+				field:       "plugin_name",
+				description: fmt.Sprintf("used resource %q as plugin name", c.Resource),
+			},
+		)
+	}
+
+	for _, f := range old.Functions {
+		s.Functions = append(s.Functions, ir.FunctionSpec{
+			UUID:  f.UUID,
+			Name:  f.Name,
+			Image: f.Image,
+		})
+	}
+
+	for _, st := range old.Streams {
+		s.Streams = append(s.Streams, ir.StreamSpec{
+			UUID:     st.UUID,
+			Name:     st.Name,
+			FromUUID: st.FromUUID,
+			ToUUID:   st.ToUUID,
+		})
+	}
+
+	names := make([]string, 0, len(old.Secrets))
+	for name := range old.Secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var changes []Change
+	for _, name := range names {
+		if err := s.AddSecret(name, ir.SecretSpec{}); err != nil {
+			changes = append(changes, Change{
+				Path:        "/secrets/" + name,
+				Description: fmt.Sprintf("dropped secret %q: %s", name, err),
+			})
+			continue
+		}
+		changes = append(changes, Change{
+			Path:        "/secrets/" + name,
+			Description: fmt.Sprintf("declared secret %q without its value, reference it as %s", name, ir.SecretRef(name)),
+		})
+	}
+
+	changes = append(changes, specVersionChange(irv1.SpecVersion_0_2_0, ir.SpecVersion_v3))
+
+	out, err := s.Marshal()
+	if err != nil {
+		return nil, nil, err
+	}
+	connectorChanges, err := resolveEntryChanges(out, entries)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, append(connectorChanges, changes...), nil
+}
+
+// upgradeV3ToV4 makes sure every stream is identified and named, and declares
+// the secrets which v3 specs held as plaintext values. Connectors and
+// functions of valid v3 specs already have a UUID.
+func upgradeV3ToV4(spec []byte) ([]byte, []Change, error) {
+	spec, plaintext, err := ir.DeclarePlaintextSecrets(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	s, err := ir.Unmarshal(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	declared := make(map[string]bool, len(plaintext))
+	for _, name := range plaintext {
+		declared[name] = true
+	}
+
+	var changes []Change
+	for _, name := range sortedKeys(s.Secrets) {
+		if err := ir.ValidateSecretName(name); err != nil {
+			delete(s.Secrets, name)
+			changes = append(changes, Change{
+				Path:        "/secrets/" + name,
+				Description: fmt.Sprintf("dropped secret %q: %s", name, err),
+			})
+			continue
+		}
+		if declared[name] {
+			changes = append(changes, Change{
+				Path:        "/secrets/" + name,
+				Description: fmt.Sprintf("declared secret %q without its value, reference it as %s", name, ir.SecretRef(name)),
+			})
+		}
+	}
+	var entries []entryChange
+	for i := range s.Streams {
+		st := &s.Streams[i]
+		if st.UUID == "" {
+			st.UUID = uuid.New().String()
+			entries = append(entries, entryChange{
+				list:        "streams",
+				uuid:        st.UUID,
+				field:       "uuid",
+				description: fmt.Sprintf("assigned uuid to stream from %s to %s", st.FromUUID, st.ToUUID),
+			})
+		}
+		if st.Name == "" {
+			st.Name = st.FromUUID + "_" + st.ToUUID
+			entries = append(entries, entryChange{
+				list:        "streams",
+				uuid:        st.UUID,
+				field:       "name",
+				description: fmt.Sprintf("named stream %q", st.Name),
+			})
+		}
+	}
+
+	s.Definition.Metadata.SpecVersion = ir.SpecVersion_v4
+
+	out, err := s.Marshal()
+	if err != nil {
+		return nil, nil, err
+	}
+	streamChanges, err := resolveEntryChanges(out, entries)
+	if err != nil {
+		return nil, nil, err
+	}
+	changes = append(changes, streamChanges...)
+	changes = append(changes, specVersionChange(ir.SpecVersion_v3, ir.SpecVersion_v4))
+	return out, changes, nil
+}
+
+// entryChange is a change to a connector or stream of a spec, which is
+// identified by its UUID until the spec is marshalled: Marshal reorders
+// connectors and streams, so their index is only known afterwards.
+type entryChange struct {
+	list        string
+	uuid        string
+	field       string
+	description string
+}
+
+// resolveEntryChanges turns entry changes into changes whose paths point
+// into the marshalled spec.
+func resolveEntryChanges(spec []byte, entries []entryChange) ([]Change, error) {
+	s, err := ir.Unmarshal(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	indices := map[string]map[string]int{
+		"connectors": {},
+		"streams":    {},
+	}
+	for i, c := range s.Connectors {
+		indices["connectors"][c.UUID] = i
+	}
+	for i, st := range s.Streams {
+		indices["streams"][st.UUID] = i
+	}
+
+	changes := make([]Change, 0, len(entries))
+	for _, e := range entries {
+		i, ok := indices[e.list][e.uuid]
+		if !ok {
+			return nil, fmt.Errorf("%s entry %q is missing from the migrated spec", e.list, e.uuid)
+		}
+		changes = append(changes, Change{
+			Path:        fmt.Sprintf("/%s/%d/%s", e.list, i, e.field),
+			Description: e.description,
+		})
+	}
+	return changes, nil
+}
+
+func specVersionChange(from, to string) Change {
+	return Change{
+		Path:        "/definition/metadata/spec_version",
+		Description: fmt.Sprintf("changed spec version from %q to %q", from, to),
+	}
+}
+
+// pluginConfig converts a 0.x connector config into plugin config values,
+// encoding anything that is not a string as JSON.
+func pluginConfig(config map[string]interface{}) (map[string]string, error) {
+	out := make(map[string]string, len(config))
+	for k, v := range config {
+		if s, ok := v.(string); ok {
+			out[k] = s
+			continue
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = string(b)
+	}
+	return out, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
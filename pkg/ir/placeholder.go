@@ -0,0 +1,131 @@
+package ir
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Placeholder is a templated plugin config value, written ${NAME} or
+// ${NAME:-default}. Placeholders are resolved against the variables of an
+// environment profile or left as is for the platform to fill in, Conduit
+// pipeline files use the same syntax for environment variables.
+//
+// Only well-formed placeholders are templated, any other "${" is kept as is.
+// A "$${" is an escaped "${", which resolves to a literal "${".
+type Placeholder struct {
+	Name       string
+	Default    string
+	HasDefault bool
+}
+
+// placeholderPattern matches escaped "${", secret references and placeholders.
+var placeholderPattern = regexp.MustCompile(`\$\$\{|\$\{secret:[^}]*\}|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-[^}]*)?\}`)
+
+func (p Placeholder) String() string {
+	if p.HasDefault {
+		return "${" + p.Name + ":-" + p.Default + "}"
+	}
+	return "${" + p.Name + "}"
+}
+
+// ValidatePlaceholderName checks that a placeholder name can be used as an
+// environment variable name.
+func ValidatePlaceholderName(name string) error {
+	if !envNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid placeholder name %q, names must match %s", name, envNamePattern)
+	}
+	return nil
+}
+
+// Placeholders returns the placeholders of a plugin config value in order of
+// appearance. Secret references are not placeholders and are skipped.
+func Placeholders(value string) []Placeholder {
+	var placeholders []Placeholder
+	expandPlaceholders(value, func(p Placeholder) string {
+		placeholders = append(placeholders, p)
+		return ""
+	})
+	return placeholders
+}
+
+// ResolvePlaceholders replaces the placeholders of a plugin config value with
+// the values returned by lookup, or their default, and unescapes "$${".
+// Secret references are kept.
+func ResolvePlaceholders(value string, lookup func(name string) (string, bool)) (string, error) {
+	var missing []string
+	resolved := expandPlaceholders(value, func(p Placeholder) string {
+		if v, ok := lookup(p.Name); ok {
+			return v
+		}
+		if !p.HasDefault {
+			missing = append(missing, p.String())
+		}
+		return p.Default
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("unresolved placeholder(s) %s", strings.Join(missing, ", "))
+	}
+	return resolved, nil
+}
+
+// expandPlaceholders replaces every placeholder of value with the result of
+// fn and unescapes "$${", the replacements are not expanded again.
+func expandPlaceholders(value string, fn func(Placeholder) string) string {
+	return placeholderPattern.ReplaceAllStringFunc(value, func(m string) string {
+		switch {
+		case m == "$${":
+			return "${"
+		case strings.HasPrefix(m, "${secret:"):
+			return m
+		}
+		sub := placeholderPattern.FindStringSubmatch(m)
+		return fn(Placeholder{
+			Name:       sub[1],
+			Default:    strings.TrimPrefix(sub[2], ":-"),
+			HasDefault: sub[2] != "",
+		})
+	})
+}
+
+// ResolvePlaceholders returns a copy of the spec with the placeholders of
+// every plugin config resolved against vars. Every placeholder without a
+// default must be resolved, all the missing ones are reported at once.
+func (d *DeploymentSpec) ResolvePlaceholders(vars map[string]string) (*DeploymentSpec, error) {
+	lookup := func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+
+	resolved := &DeploymentSpec{
+		Connectors: make([]ConnectorSpec, len(d.Connectors)),
+		Functions:  d.Functions,
+		Streams:    d.Streams,
+		Secrets:    d.Secrets,
+		Definition: d.Definition,
+	}
+
+	var errs []string
+	for i, c := range d.Connectors {
+		if c.PluginConfig != nil {
+			config := make(map[string]string, len(c.PluginConfig))
+			for _, k := range sortedKeys(c.PluginConfig) {
+				v, err := ResolvePlaceholders(c.PluginConfig[k], lookup)
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("config %q of connector %q: %s", k, c.Name, err))
+					continue
+				}
+				config[k] = v
+			}
+			c.PluginConfig = config
+		}
+		resolved.Connectors[i] = c
+	}
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return nil, errors.New(strings.Join(errs, "; "))
+	}
+	return resolved, nil
+}
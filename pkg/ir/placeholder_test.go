@@ -0,0 +1,112 @@
+package ir_test
+
+import (
+	"testing"
+
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Placeholders(t *testing.T) {
+	testCases := []struct {
+		desc  string
+		value string
+		want  []ir.Placeholder
+	}{
+		{
+			desc:  "no placeholders",
+			value: "orders",
+		},
+		{
+			desc:  "placeholders and secrets",
+			value: "postgres://${PG_USER}:${secret:PG_PASSWORD}@${PG_HOST:-localhost}:${PG_PORT:-}/app",
+			want: []ir.Placeholder{
+				{Name: "PG_USER"},
+				{Name: "PG_HOST", Default: "localhost", HasDefault: true},
+				{Name: "PG_PORT", HasDefault: true},
+			},
+		},
+		{
+			desc:  "invalid names are literal",
+			value: "orders_${env-name}",
+		},
+		{
+			desc:  "unterminated placeholders are literal",
+			value: "price ${5",
+		},
+		{
+			desc:  "escaped placeholders",
+			value: "$${ENV}_${REGION}",
+			want:  []ir.Placeholder{{Name: "REGION"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.want, ir.Placeholders(tc.value))
+		})
+	}
+}
+
+func Test_ResolvePlaceholders(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		v, ok := map[string]string{"ENV": "prod", "NESTED": "${ENV}"}[name]
+		return v, ok
+	}
+
+	got, err := ir.ResolvePlaceholders("orders_${ENV}_${REGION:-eu}_${NESTED} ${secret:KEY}", lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "orders_prod_eu_${ENV} ${secret:KEY}", got)
+
+	got, err = ir.ResolvePlaceholders("$${ENV} is ${ENV}, price ${5 ${env-name}", lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "${ENV} is prod, price ${5 ${env-name}", got)
+
+	_, err = ir.ResolvePlaceholders("${TABLE}_${ENV}_${SCHEMA}", lookup)
+	assert.EqualError(t, err, "unresolved placeholder(s) ${TABLE}, ${SCHEMA}")
+}
+
+func Test_DeploymentSpec_ResolvePlaceholders(t *testing.T) {
+	spec := &ir.DeploymentSpec{
+		Definition: ir.DefinitionSpec{
+			Metadata: ir.MetadataSpec{SpecVersion: ir.LatestSpecVersion},
+		},
+		Connectors: []ir.ConnectorSpec{
+			{
+				UUID: "1", Name: "pg", PluginType: ir.PluginSource, PluginName: "postgres",
+				PluginConfig: map[string]string{"table": "orders_${ENV}", "schema": "${SCHEMA}"},
+			},
+			{
+				UUID: "2", Name: "s3", PluginType: ir.PluginDestination, PluginName: "s3",
+				PluginConfig: map[string]string{"bucket": "${BUCKET}"},
+			},
+		},
+		Streams: []ir.StreamSpec{
+			{UUID: "1_2", Name: "pg_s3", FromUUID: "1", ToUUID: "2"},
+		},
+	}
+
+	// Placeholders are kept when the spec is not resolved.
+	symbolic, err := spec.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, string(symbolic), `"table":"orders_${ENV}"`)
+
+	_, err = spec.ResolvePlaceholders(map[string]string{"ENV": "dev"})
+	assert.EqualError(t, err, `config "bucket" of connector "s3": unresolved placeholder(s) ${BUCKET}; config "schema" of connector "pg": unresolved placeholder(s) ${SCHEMA}`)
+
+	resolved, err := spec.ResolvePlaceholders(map[string]string{"ENV": "dev", "SCHEMA": "public", "BUCKET": "archive-dev"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"table": "orders_dev", "schema": "public"}, resolved.Connectors[0].PluginConfig)
+	assert.Equal(t, map[string]string{"bucket": "archive-dev"}, resolved.Connectors[1].PluginConfig)
+	assert.Equal(t, "orders_${ENV}", spec.Connectors[0].PluginConfig["table"])
+
+	_, err = resolved.Marshal()
+	require.NoError(t, err)
+
+	// Values which are not placeholders are kept as is.
+	spec.Connectors[1].PluginConfig["bucket"] = "price ${5"
+	literal, err := spec.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, string(literal), `"bucket":"price ${5"`)
+}
@@ -0,0 +1,387 @@
+package ir
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/heimdalr/dag"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Machine-readable codes of spec violations. Schema violations use the
+// "schema." prefix followed by the failing JSON schema keyword.
+const (
+	CodeInvalidJSON        = "spec.invalid_json"
+	CodeInvalidSchema      = "spec.invalid_schema"
+	CodeInvalidVersion     = "spec.invalid_version"
+	CodePlaintextSecret    = "spec.plaintext_secret"
+	CodeDuplicateID        = "dag.duplicate_id"
+	CodeUnknownStreamEnd   = "dag.unknown_stream_endpoint"
+	CodeDuplicateStream    = "dag.duplicate_stream"
+	CodeCycle              = "dag.cycle"
+	CodeInvalidStream      = "dag.invalid_stream"
+	CodeNoResources        = "dag.no_resources"
+	CodeNoSources          = "dag.no_sources"
+	CodeNoStreams          = "dag.no_streams"
+	CodeNotConnected       = "dag.not_connected"
+	CodeSourceWithInput    = "dag.source_with_input"
+	CodeDestinationOutput  = "dag.destination_with_output"
+	CodeResourceNoInput    = "dag.no_input"
+	schemaViolationCodeFmt = "schema.%s"
+)
+
+// Violation is a single problem found while validating a spec.
+type Violation struct {
+	// Pointer is the JSON pointer of the offending value in the spec.
+	Pointer string `json:"pointer"`
+	// Resource is the name of the offending connector or function, if any.
+	Resource string   `json:"resource,omitempty"`
+	Severity Severity `json:"severity"`
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+}
+
+// ValidationReport lists every violation found in a spec.
+type ValidationReport struct {
+	Violations []Violation `json:"violations"`
+}
+
+func (r *ValidationReport) Add(v ...Violation) {
+	r.Violations = append(r.Violations, v...)
+}
+
+// HasErrors reports whether any violation has the error severity.
+func (r *ValidationReport) HasErrors() bool {
+	for _, v := range r.Violations {
+		if v.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Err returns the report as an error if it contains any error violation.
+func (r *ValidationReport) Err() error {
+	if !r.HasErrors() {
+		return nil
+	}
+	return &ValidationReportError{Report: r}
+}
+
+func (r *ValidationReport) MarshalJSON() ([]byte, error) {
+	violations := r.Violations
+	if violations == nil {
+		violations = []Violation{}
+	}
+	return json.Marshal(struct {
+		Valid      bool        `json:"valid"`
+		Violations []Violation `json:"violations"`
+	}{
+		Valid:      !r.HasErrors(),
+		Violations: violations,
+	})
+}
+
+type ValidationReportError struct {
+	Report *ValidationReport
+}
+
+func (e *ValidationReportError) Error() string {
+	msgs := make([]string, 0, len(e.Report.Violations))
+	for _, v := range e.Report.Violations {
+		if v.Severity == SeverityError {
+			msgs = append(msgs, fmt.Sprintf("%s: %s", v.Pointer, v.Message))
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// SchemaViolations turns every leaf cause of a JSON schema validation error into
+// a violation. The marshalled spec is used to name the offending resources.
+func SchemaViolations(err error, spec []byte) []Violation {
+	var e *jsonschema.ValidationError
+	if !errors.As(err, &e) {
+		return []Violation{{
+			Severity: SeverityError,
+			Code:     CodeInvalidJSON,
+			Message:  err.Error(),
+		}}
+	}
+
+	var doc interface{}
+	_ = json.Unmarshal(spec, &doc)
+
+	var violations []Violation
+	for _, leaf := range leafErrors(e) {
+		violations = append(violations, Violation{
+			Pointer:  leaf.InstanceLocation,
+			Resource: resourceAt(doc, leaf.InstanceLocation),
+			Severity: SeverityError,
+			Code:     fmt.Sprintf(schemaViolationCodeFmt, path.Base(leaf.KeywordLocation)),
+			Message:  fmt.Sprintf("%q field fails %s validation: %s", leaf.InstanceLocation, leaf.KeywordLocation, leaf.Message),
+		})
+	}
+	return violations
+}
+
+func leafErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+
+	var leaves []*jsonschema.ValidationError
+	for _, c := range err.Causes {
+		leaves = append(leaves, leafErrors(c)...)
+	}
+	return leaves
+}
+
+// resourceAt returns the name of the connector or function containing the pointer.
+func resourceAt(doc interface{}, pointer string) string {
+	parts := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	if len(parts) < 2 || (parts[0] != "connectors" && parts[0] != "functions") {
+		return ""
+	}
+
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	items, ok := root[parts[0]].([]interface{})
+	if !ok {
+		return ""
+	}
+	i, err := strconv.Atoi(parts[1])
+	if err != nil || i < 0 || i >= len(items) {
+		return ""
+	}
+	item, ok := items[i].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	name, _ := item["name"].(string)
+	return name
+}
+
+// NewValidationReport validates a marshalled spec against a JSON schema and
+// its DAG, reporting every violation found.
+func NewValidationReport(sch *jsonschema.Schema, spec []byte) *ValidationReport {
+	report := &ValidationReport{}
+
+	var v interface{}
+	if err := json.Unmarshal(spec, &v); err != nil {
+		report.Add(SchemaViolations(err, spec)...)
+		return report
+	}
+
+	if err := sch.Validate(v); err != nil {
+		report.Add(SchemaViolations(err, spec)...)
+	}
+
+	spec, plaintext, err := DeclarePlaintextSecrets(spec)
+	if err != nil {
+		// Mistyped fields are already reported by the schema.
+		return report
+	}
+	for _, name := range plaintext {
+		report.Add(Violation{
+			Pointer:  "/secrets/" + pointerToken(name),
+			Severity: SeverityWarning,
+			Code:     CodePlaintextSecret,
+			Message:  fmt.Sprintf("secret %q holds a plaintext value, which is dropped when upgrading the spec", name),
+		})
+	}
+
+	d, err := Unmarshal(spec)
+	if err != nil {
+		// Mistyped fields are already reported by the schema.
+		return report
+	}
+	report.Add(d.ValidationReport().Violations...)
+
+	return report
+}
+
+// ValidationReport builds the DAG of the spec and reports every structural
+// violation instead of stopping at the first one.
+func (d *DeploymentSpec) ValidationReport() *ValidationReport {
+	report := &ValidationReport{}
+
+	if err := ValidateSpecVersion(d.getSpecVersion()); err != nil {
+		report.Add(Violation{
+			Pointer:  "/definition/metadata/spec_version",
+			Severity: SeverityError,
+			Code:     CodeInvalidVersion,
+			Message:  err.Error(),
+		})
+	}
+
+	turbineDag, violations := d.buildDAGReport()
+	report.Add(violations...)
+	report.Add(d.dagViolations(turbineDag)...)
+	return report
+}
+
+// buildDAGReport builds as much of the DAG as possible, reporting the
+// resources and streams that could not be added to it.
+func (d *DeploymentSpec) buildDAGReport() (*dag.DAG, []Violation) {
+	var violations []Violation
+
+	turbineDag := dag.NewDAG()
+	for i := range d.Connectors {
+		con := &d.Connectors[i]
+		if err := turbineDag.AddVertexByID(con.UUID, con); err != nil {
+			violations = append(violations, Violation{
+				Pointer:  fmt.Sprintf("/connectors/%d/uuid", i),
+				Resource: con.Name,
+				Severity: SeverityError,
+				Code:     CodeDuplicateID,
+				Message:  err.Error(),
+			})
+		}
+	}
+	for i := range d.Functions {
+		fun := &d.Functions[i]
+		if err := turbineDag.AddVertexByID(fun.UUID, fun); err != nil {
+			violations = append(violations, Violation{
+				Pointer:  fmt.Sprintf("/functions/%d/uuid", i),
+				Resource: fun.Name,
+				Severity: SeverityError,
+				Code:     CodeDuplicateID,
+				Message:  err.Error(),
+			})
+		}
+	}
+	for i, stream := range d.Streams {
+		if err := turbineDag.AddEdge(stream.FromUUID, stream.ToUUID); err != nil {
+			violations = append(violations, Violation{
+				Pointer:  fmt.Sprintf("/streams/%d", i),
+				Severity: SeverityError,
+				Code:     streamErrorCode(err),
+				Message:  err.Error(),
+			})
+		}
+	}
+
+	return turbineDag, violations
+}
+
+func streamErrorCode(err error) string {
+	var (
+		unknown   dag.IDUnknownError
+		empty     dag.IDEmptyError
+		duplicate dag.EdgeDuplicateError
+		loop      dag.EdgeLoopError
+		same      dag.SrcDstEqualError
+	)
+	switch {
+	case errors.As(err, &unknown), errors.As(err, &empty):
+		return CodeUnknownStreamEnd
+	case errors.As(err, &duplicate):
+		return CodeDuplicateStream
+	case errors.As(err, &loop), errors.As(err, &same):
+		return CodeCycle
+	default:
+		return CodeInvalidStream
+	}
+}
+
+// dagViolations reports every topology violation of the DAG, see ValidateDAG.
+func (d *DeploymentSpec) dagViolations(turbineDag *dag.DAG) []Violation {
+	violation := func(code, msg string) []Violation {
+		return []Violation{{
+			Severity: SeverityError,
+			Code:     code,
+			Message:  msg,
+		}}
+	}
+
+	if turbineDag == nil {
+		return violation(CodeNoResources, "invalid DAG, no resources found")
+	}
+
+	if len(turbineDag.GetRoots()) == 0 {
+		return violation(CodeNoSources, "invalid DAG, no sources found")
+	}
+
+	// No edges
+	if turbineDag.GetSize() == 0 {
+		return violation(CodeNoStreams, "invalid DAG, there has to be at least one source streaming to a function or a destination")
+	}
+
+	vertices := turbineDag.GetVertices()
+
+	var violations []Violation
+	for _, id := range sortedIDs(vertices) {
+		parents, _ := turbineDag.GetParents(id)
+		children, _ := turbineDag.GetChildren(id)
+
+		kind, name := describeVertex(vertices[id])
+		label := name
+		if label == "" {
+			label = id
+		}
+
+		v := Violation{
+			Pointer:  d.pointerOf(id),
+			Resource: name,
+			Severity: SeverityError,
+		}
+		switch {
+		case len(parents) == 0 && len(children) == 0:
+			v.Code = CodeNotConnected
+			v.Message = fmt.Sprintf("invalid DAG, %s %q is not connected to any resource", kind, label)
+		case kind == string(PluginSource) && len(parents) > 0:
+			v.Code = CodeSourceWithInput
+			v.Message = fmt.Sprintf("invalid DAG, source %q cannot receive records", label)
+		case kind == string(PluginDestination) && len(children) > 0:
+			v.Code = CodeDestinationOutput
+			v.Message = fmt.Sprintf("invalid DAG, destination %q cannot stream records", label)
+		case kind != string(PluginSource) && len(parents) == 0:
+			v.Code = CodeResourceNoInput
+			v.Message = fmt.Sprintf("invalid DAG, %s %q has no input stream", kind, label)
+		default:
+			continue
+		}
+		violations = append(violations, v)
+	}
+
+	return violations
+}
+
+// pointerOf returns the JSON pointer of the connector or function with the given UUID.
+func (d *DeploymentSpec) pointerOf(id string) string {
+	for i := range d.Connectors {
+		if d.Connectors[i].UUID == id {
+			return fmt.Sprintf("/connectors/%d", i)
+		}
+	}
+	for i := range d.Functions {
+		if d.Functions[i].UUID == id {
+			return fmt.Sprintf("/functions/%d", i)
+		}
+	}
+	return ""
+}
+
+// sortedIDs returns the vertex IDs of the DAG in a stable order.
+func sortedIDs(vertices map[string]interface{}) []string {
+	ids := make([]string, 0, len(vertices))
+	for id := range vertices {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
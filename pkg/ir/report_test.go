@@ -0,0 +1,100 @@
+package ir_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidationReport(t *testing.T) {
+	spec := ir.DeploymentSpec{
+		Definition: ir.DefinitionSpec{
+			Metadata: ir.MetadataSpec{SpecVersion: "0.0.0"},
+		},
+		Connectors: []ir.ConnectorSpec{
+			{UUID: "1", Name: "pg", PluginType: ir.PluginSource},
+			{UUID: "2", Name: "unused", PluginType: ir.PluginSource},
+			{UUID: "3", Name: "s3", PluginType: ir.PluginDestination},
+			{UUID: "3", Name: "s3_copy", PluginType: ir.PluginDestination},
+		},
+		Functions: []ir.FunctionSpec{
+			{UUID: "4", Name: "anonymize"},
+		},
+		Streams: []ir.StreamSpec{
+			{UUID: "1_3", FromUUID: "1", ToUUID: "3"},
+			{UUID: "3_4", FromUUID: "3", ToUUID: "4"},
+			{UUID: "1_5", FromUUID: "1", ToUUID: "5"},
+		},
+	}
+
+	report := spec.ValidationReport()
+	require.True(t, report.HasErrors())
+	assert.Equal(t, []ir.Violation{
+		{
+			Pointer:  "/definition/metadata/spec_version",
+			Severity: ir.SeverityError,
+			Code:     ir.CodeInvalidVersion,
+			Message:  "spec version \"0.0.0\" is invalid, supported versions: v3, v4",
+		},
+		{
+			Pointer:  "/connectors/3/uuid",
+			Resource: "s3_copy",
+			Severity: ir.SeverityError,
+			Code:     ir.CodeDuplicateID,
+			Message:  "the id '3' is already known",
+		},
+		{
+			Pointer:  "/streams/2",
+			Severity: ir.SeverityError,
+			Code:     ir.CodeUnknownStreamEnd,
+			Message:  "'5' is unknown",
+		},
+		{
+			Pointer:  "/connectors/1",
+			Resource: "unused",
+			Severity: ir.SeverityError,
+			Code:     ir.CodeNotConnected,
+			Message:  "invalid DAG, source \"unused\" is not connected to any resource",
+		},
+		{
+			Pointer:  "/connectors/2",
+			Resource: "s3",
+			Severity: ir.SeverityError,
+			Code:     ir.CodeDestinationOutput,
+			Message:  "invalid DAG, destination \"s3\" cannot stream records",
+		},
+	}, report.Violations)
+
+	b, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"valid":false`)
+	assert.Contains(t, string(b), `{"pointer":"/connectors/2","resource":"s3","severity":"error","code":"dag.destination_with_output","message":"invalid DAG, destination \"s3\" cannot stream records"}`)
+
+	assert.ErrorContains(t, report.Err(), "/streams/2: '5' is unknown")
+}
+
+func Test_ValidationReport_Valid(t *testing.T) {
+	spec := ir.DeploymentSpec{
+		Definition: ir.DefinitionSpec{
+			Metadata: ir.MetadataSpec{SpecVersion: ir.LatestSpecVersion},
+		},
+		Connectors: []ir.ConnectorSpec{
+			{UUID: "1", Name: "pg", PluginType: ir.PluginSource},
+			{UUID: "2", Name: "s3", PluginType: ir.PluginDestination},
+		},
+		Streams: []ir.StreamSpec{
+			{UUID: "1_2", FromUUID: "1", ToUUID: "2"},
+		},
+	}
+
+	report := spec.ValidationReport()
+	assert.False(t, report.HasErrors())
+	assert.NoError(t, report.Err())
+
+	b, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"valid":true,"violations":[]}`, string(b))
+}
@@ -0,0 +1,89 @@
+package ir
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// Schema is the JSON schema of a spec package, along with the spec versions
+// that package validates against it.
+type Schema struct {
+	Source   string
+	Versions []string
+}
+
+// checkVersion rejects unknown spec versions, and the ones which belong to
+// the schema of another spec package.
+func (s Schema) checkVersion(ver string) error {
+	if err := ValidateSpecVersion(ver); err != nil {
+		return err
+	}
+	for _, v := range s.Versions {
+		if v == ver {
+			return nil
+		}
+	}
+	return fmt.Errorf(
+		"spec version %q is not supported by this schema, supported versions: %s",
+		ver,
+		strings.Join(s.Versions, ", "),
+	)
+}
+
+func (s Schema) compile() (*jsonschema.Schema, error) {
+	return jsonschema.CompileString("turbine.ir.schema.json", s.Source)
+}
+
+// Validate reports every schema and DAG violation of the spec instead of
+// only the first one.
+func (s Schema) Validate(spec []byte, specVersion string) *ValidationReport {
+	if err := s.checkVersion(specVersion); err != nil {
+		return &ValidationReport{
+			Violations: []Violation{{
+				Pointer:  "/definition/metadata/spec_version",
+				Severity: SeverityError,
+				Code:     CodeInvalidVersion,
+				Message:  err.Error(),
+			}},
+		}
+	}
+
+	sch, err := s.compile()
+	if err != nil {
+		return &ValidationReport{
+			Violations: []Violation{{
+				Severity: SeverityError,
+				Code:     CodeInvalidSchema,
+				Message:  err.Error(),
+			}},
+		}
+	}
+
+	return NewValidationReport(sch, spec)
+}
+
+// ValidateSpec returns the first schema violation of the spec.
+func (s Schema) ValidateSpec(spec []byte, specVersion string) error {
+	if err := s.checkVersion(specVersion); err != nil {
+		return err
+	}
+
+	sch, err := s.compile()
+	if err != nil {
+		return err
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(spec, &v); err != nil {
+		return err
+	}
+
+	if err := sch.Validate(v); err != nil {
+		return NewSpecValidationError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package ir
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SecretSpec declares a secret used by the plugin config of connectors. The
+// spec only holds the name of a secret, its value is provided where the app
+// runs and never recorded.
+type SecretSpec struct {
+	Description string `json:"description,omitempty"`
+}
+
+// ErrPlaintextSecret is returned when unmarshalling a secret holding its
+// plaintext value, as specs of version v3 did, see DeclarePlaintextSecrets.
+var ErrPlaintextSecret = errors.New("secret holds a plaintext value, upgrade the spec to declare it without its value")
+
+// UnmarshalJSON rejects the plaintext values of secrets found in specs of
+// version v3 with ErrPlaintextSecret, rather than dropping them unnoticed.
+func (s *SecretSpec) UnmarshalJSON(data []byte) error {
+	var value string
+	if json.Unmarshal(data, &value) == nil {
+		return ErrPlaintextSecret
+	}
+
+	type secretSpec SecretSpec
+	return json.Unmarshal(data, (*secretSpec)(s))
+}
+
+// DeclarePlaintextSecrets returns a marshalled spec where the secrets holding
+// a plaintext value are declared without it, along with the sorted names of
+// those secrets. Other secrets and fields are left untouched.
+func DeclarePlaintextSecrets(spec []byte) ([]byte, []string, error) {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, nil, err
+	}
+	var secrets map[string]json.RawMessage
+	if raw, ok := doc["secrets"]; ok {
+		if err := json.Unmarshal(raw, &secrets); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var names []string
+	for name, raw := range secrets {
+		var value string
+		if json.Unmarshal(raw, &value) == nil {
+			secrets[name] = json.RawMessage("{}")
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return spec, nil, nil
+	}
+	sort.Strings(names)
+
+	raw, err := json.Marshal(secrets)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc["secrets"] = raw
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, names, nil
+}
+
+var (
+	envNamePattern   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	secretRefPattern = regexp.MustCompile(`\$\{secret:([^}]*)\}`)
+)
+
+// SecretRef returns the reference to a secret to use in plugin config values,
+// e.g. "${secret:PG_URL}".
+func SecretRef(name string) string {
+	return "${secret:" + name + "}"
+}
+
+// SecretRefs returns the names of the secrets referenced by a plugin config
+// value, in order of appearance.
+func SecretRefs(value string) []string {
+	var names []string
+	for _, m := range secretRefPattern.FindAllStringSubmatch(value, -1) {
+		names = append(names, m[1])
+	}
+	return names
+}
+
+// ResolveSecrets replaces the secret references of a plugin config value with
+// the values returned by lookup.
+func ResolveSecrets(value string, lookup func(name string) (string, bool)) (string, error) {
+	var err error
+	resolved := secretRefPattern.ReplaceAllStringFunc(value, func(ref string) string {
+		name := secretRefPattern.FindStringSubmatch(ref)[1]
+		v, ok := lookup(name)
+		if !ok && err == nil {
+			err = fmt.Errorf("secret %q is not set", name)
+		}
+		return v
+	})
+	if err != nil {
+		return "", err
+	}
+	return resolved, nil
+}
+
+// ValidateSecretName checks that a secret name can be used as an environment
+// variable name.
+func ValidateSecretName(name string) error {
+	if !envNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid secret name %q, names must match %s", name, envNamePattern)
+	}
+	return nil
+}
+
+// AddSecret declares a secret, declaring it again replaces its description.
+func (d *DeploymentSpec) AddSecret(name string, s SecretSpec) error {
+	if err := ValidateSecretName(name); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.Secrets == nil {
+		d.Secrets = make(map[string]SecretSpec)
+	}
+	d.Secrets[name] = s
+	return nil
+}
+
+// ValidateSecrets checks that the secrets referenced by the plugin config of
+// every connector are declared.
+func (d *DeploymentSpec) ValidateSecrets() error {
+	for _, c := range d.Connectors {
+		for _, k := range sortedKeys(c.PluginConfig) {
+			for _, name := range SecretRefs(c.PluginConfig[k]) {
+				if _, ok := d.Secrets[name]; !ok {
+					return fmt.Errorf("config %q of connector %q references undeclared secret %q", k, c.Name, name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// sensitiveConfigKey reports whether a plugin config key usually holds a
+// credential, which should be referenced as a secret.
+func sensitiveConfigKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range []string{"password", "secret", "token", "credential", "private_key", "access_key"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package ir_test
+
+import (
+	"testing"
+
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func secretSpec() *ir.DeploymentSpec {
+	return &ir.DeploymentSpec{
+		Definition: ir.DefinitionSpec{
+			Metadata: ir.MetadataSpec{SpecVersion: ir.LatestSpecVersion},
+		},
+		Connectors: []ir.ConnectorSpec{
+			{
+				UUID: "1", Name: "pg", PluginType: ir.PluginSource, PluginName: "postgres",
+				PluginConfig: map[string]string{
+					"url":      "postgres://app:${secret:PG_PASSWORD}@localhost/app",
+					"password": "hunter2",
+				},
+			},
+			{
+				UUID: "2", Name: "s3", PluginType: ir.PluginDestination, PluginName: "s3",
+				PluginConfig: map[string]string{
+					"aws.secretAccessKey": "${secret:AWS_SECRET}",
+				},
+			},
+		},
+		Streams: []ir.StreamSpec{
+			{UUID: "1_2", Name: "pg_s3", FromUUID: "1", ToUUID: "2"},
+		},
+	}
+}
+
+func Test_SecretRefs(t *testing.T) {
+	assert.Equal(t, "${secret:PG_URL}", ir.SecretRef("PG_URL"))
+	assert.Equal(t, []string{"USER", "PASSWORD"}, ir.SecretRefs("${secret:USER}:${secret:PASSWORD}@host"))
+	assert.Empty(t, ir.SecretRefs("${env:USER} $secret:USER"))
+}
+
+func Test_ResolveSecrets(t *testing.T) {
+	lookup := func(name string) (string, bool) {
+		v, ok := map[string]string{"USER": "app", "PASSWORD": "p@ss"}[name]
+		return v, ok
+	}
+
+	got, err := ir.ResolveSecrets("${secret:USER}:${secret:PASSWORD}@host", lookup)
+	require.NoError(t, err)
+	assert.Equal(t, "app:p@ss@host", got)
+
+	_, err = ir.ResolveSecrets("${secret:USER}/${secret:MISSING}", lookup)
+	assert.EqualError(t, err, `secret "MISSING" is not set`)
+}
+
+func Test_AddSecret(t *testing.T) {
+	spec := &ir.DeploymentSpec{}
+	require.NoError(t, spec.AddSecret("PG_PASSWORD", ir.SecretSpec{}))
+	require.NoError(t, spec.AddSecret("PG_PASSWORD", ir.SecretSpec{Description: "database password"}))
+	assert.Equal(t, map[string]ir.SecretSpec{"PG_PASSWORD": {Description: "database password"}}, spec.Secrets)
+
+	assert.EqualError(t, spec.AddSecret("pg-password", ir.SecretSpec{}),
+		`invalid secret name "pg-password", names must match ^[A-Za-z_][A-Za-z0-9_]*$`)
+}
+
+func Test_Marshal_Secrets(t *testing.T) {
+	spec := secretSpec()
+	_, err := spec.Marshal()
+	assert.EqualError(t, err, `config "url" of connector "pg" references undeclared secret "PG_PASSWORD"`)
+
+	require.NoError(t, spec.AddSecret("PG_PASSWORD", ir.SecretSpec{Description: "database password"}))
+	require.NoError(t, spec.AddSecret("AWS_SECRET", ir.SecretSpec{}))
+	got, err := spec.Marshal()
+	require.NoError(t, err)
+	assert.Contains(t, string(got), `"secrets":{"AWS_SECRET":{},"PG_PASSWORD":{"description":"database password"}}`)
+
+	back, err := ir.Unmarshal(got)
+	require.NoError(t, err)
+	assert.Equal(t, spec.Secrets, back.Secrets)
+}
+
+func Test_Lint_Secrets(t *testing.T) {
+	spec := secretSpec()
+	require.NoError(t, spec.AddSecret("AWS_SECRET", ir.SecretSpec{}))
+	require.NoError(t, spec.AddSecret("UNUSED", ir.SecretSpec{}))
+
+	assert.Equal(t, []ir.Violation{
+		{
+			Pointer:  "/connectors/0/plugin_config/url",
+			Resource: "pg",
+			Severity: ir.SeverityError,
+			Code:     ir.LintUndeclaredSecret,
+			Message:  "connector \"pg\" references undeclared secret \"PG_PASSWORD\"",
+		},
+		{
+			Pointer:  "/secrets/UNUSED",
+			Severity: ir.SeverityWarning,
+			Code:     ir.LintUnusedSecret,
+			Message:  "secret \"UNUSED\" is not referenced by any connector",
+		},
+		{
+			Pointer:  "/connectors/0/plugin_config/password",
+			Resource: "pg",
+			Severity: ir.SeverityWarning,
+			Code:     ir.LintPlaintextCredential,
+			Message:  "config \"password\" of connector \"pg\" holds a plaintext credential, reference a secret instead",
+		},
+	}, spec.Lint(ir.LintOptions{}).Violations)
+}
+
+func Test_Lint_Secrets_EscapesPointers(t *testing.T) {
+	spec := &ir.DeploymentSpec{
+		Definition: ir.DefinitionSpec{
+			Metadata: ir.MetadataSpec{SpecVersion: ir.LatestSpecVersion},
+		},
+		Connectors: []ir.ConnectorSpec{
+			{
+				UUID:       "1",
+				Name:       "http",
+				PluginType: ir.PluginSource,
+				PluginConfig: map[string]string{
+					"headers/x~token": ir.SecretRef("TOKEN"),
+				},
+			},
+		},
+	}
+
+	report := spec.Lint(ir.LintOptions{Suppress: []string{ir.LintSourceWithoutOutput}})
+	require.Len(t, report.Violations, 1)
+	assert.Equal(t, "/connectors/0/plugin_config/headers~1x~0token", report.Violations[0].Pointer)
+}
@@ -2,6 +2,7 @@ package ir
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -23,22 +24,27 @@ const (
 	PluginSource      DirectionType = "source"
 	PluginDestination DirectionType = "destination"
 
-	SpecVersion_v3    = "v3"
+	SpecVersion_v3 = "v3"
+	SpecVersion_v4 = "v4"
+	// LatestSpecVersion is the version of the specs built by turbine. Specs
+	// in v4 are accepted, but only built once the platform supports them.
 	LatestSpecVersion = SpecVersion_v3
 )
 
 var specVersions = []string{
 	SpecVersion_v3,
+	SpecVersion_v4,
 }
 
 type DeploymentSpec struct {
 	mu          sync.Mutex
 	turbineDag  dag.DAG
 	dagInitOnce sync.Once
-	Connectors  []ConnectorSpec `json:"connectors"`
-	Functions   []FunctionSpec  `json:"functions,omitempty"`
-	Streams     []StreamSpec    `json:"streams,omitempty"`
-	Definition  DefinitionSpec  `json:"definition"`
+	Connectors  []ConnectorSpec       `json:"connectors"`
+	Functions   []FunctionSpec        `json:"functions,omitempty"`
+	Streams     []StreamSpec          `json:"streams,omitempty"`
+	Secrets     map[string]SecretSpec `json:"secrets,omitempty"`
+	Definition  DefinitionSpec        `json:"definition"`
 }
 
 type StreamSpec struct {
@@ -105,11 +111,16 @@ func (d *DeploymentSpec) SetImageForFunctions(image string) error {
 	return nil
 }
 
+// Marshal returns the canonical JSON form of the spec, which only depends on the
+// described deployment and not on the order in which resources were added.
 func (d *DeploymentSpec) Marshal() ([]byte, error) {
 	if _, err := d.BuildDAG(); err != nil {
 		return nil, err
 	}
-	return json.Marshal(d)
+	if err := d.ValidateSecrets(); err != nil {
+		return nil, err
+	}
+	return d.canonicalJSON()
 }
 
 func Unmarshal(data []byte) (*DeploymentSpec, error) {
@@ -170,25 +181,31 @@ func (d *DeploymentSpec) AddStream(s *StreamSpec) error {
 	return d.turbineDag.AddEdge(s.FromUUID, s.ToUUID)
 }
 
+// ValidateDAG checks that the topology is supported: every root of the DAG is a source,
+// sources may fan out and functions or destinations may fan in, destinations do not
+// write to other resources and no resource is left unconnected.
+// Cycles are already rejected when streams are added to the DAG.
 func (d *DeploymentSpec) ValidateDAG(turbineDag *dag.DAG) error {
-	if turbineDag == nil {
-		return fmt.Errorf("invalid DAG, no resources found")
-	}
-
-	if len(turbineDag.GetRoots()) > 1 {
-		return fmt.Errorf("invalid DAG, too many sources")
-	}
-
-	if len(turbineDag.GetRoots()) == 0 {
-		return fmt.Errorf("invalid DAG, no sources found")
+	if violations := d.dagViolations(turbineDag); len(violations) > 0 {
+		return errors.New(violations[0].Message)
 	}
+	return nil
+}
 
-	// No edges
-	if turbineDag.GetSize() == 0 {
-		return fmt.Errorf("invalid DAG, there has to be at least one source, at most one function, and zero or more destinations")
+// describeVertex returns the kind and name of a resource stored in the DAG.
+func describeVertex(v interface{}) (kind, name string) {
+	switch r := v.(type) {
+	case *ConnectorSpec:
+		return string(r.PluginType), r.Name
+	case **ConnectorSpec:
+		return string((*r).PluginType), (*r).Name
+	case *FunctionSpec:
+		return "function", r.Name
+	case **FunctionSpec:
+		return "function", (*r).Name
+	default:
+		return "resource", ""
 	}
-
-	return nil
 }
 
 func (d *DeploymentSpec) getSpecVersion() string {
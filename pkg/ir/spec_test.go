@@ -26,7 +26,7 @@ func TestDeploymentSpec_BuildDAG_UnsupportedSpec(t *testing.T) {
 	}
 
 	_, err = spec.BuildDAG()
-	assert.ErrorContains(t, err, "spec version \"0.0.0\" is invalid, supported versions: v3")
+	assert.ErrorContains(t, err, "spec version \"0.0.0\" is invalid, supported versions: v3, v4")
 }
 
 func TestDeploymentSpec_BuildDAG_EmptySpec(t *testing.T) {
@@ -41,7 +41,7 @@ func TestDeploymentSpec_BuildDAG_EmptySpec(t *testing.T) {
 	}
 
 	_, err = spec.BuildDAG()
-	assert.ErrorContains(t, err, "spec version \"\" is invalid, supported versions: v3")
+	assert.ErrorContains(t, err, "spec version \"\" is invalid, supported versions: v3, v4")
 }
 
 func Test_DeploymentSpec(t *testing.T) {
@@ -77,6 +77,10 @@ func Test_DeploymentSpec(t *testing.T) {
 				Image: "ftorres/enrich:9",
 			},
 		},
+		Secrets: map[string]ir.SecretSpec{
+			// The plaintext value of the secret is dropped.
+			"key": {},
+		},
 		Definition: ir.DefinitionSpec{
 			GitSha: "3630e05a-98b7-43a0-aeb0-c9b5b0d4261c",
 			Metadata: ir.MetadataSpec{
@@ -103,6 +107,14 @@ func Test_DeploymentSpec(t *testing.T) {
 		},
 	}
 
+	// v3 specs hold the plaintext values of their secrets, which are reported.
+	err = json.Unmarshal(jsonSpec, &ir.DeploymentSpec{})
+	require.ErrorIs(t, err, ir.ErrPlaintextSecret)
+
+	jsonSpec, plaintext, err := ir.DeclarePlaintextSecrets(jsonSpec)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"key"}, plaintext)
+
 	deploySpec := &ir.DeploymentSpec{}
 	if err := json.Unmarshal(jsonSpec, deploySpec); err != nil {
 		t.Fatal(err)
@@ -119,13 +131,13 @@ func Test_ValidateVersion(t *testing.T) {
 	}{
 		{
 			name:         "using valid spec version",
-			specVersions: []string{"v3"},
+			specVersions: []string{"v3", "v4"},
 			wantError:    nil,
 		},
 		{
 			name:         "using invalid spec version",
 			specVersions: []string{"0.0.0"},
-			wantError:    fmt.Errorf("spec version \"0.0.0\" is invalid, supported versions: v3"),
+			wantError:    fmt.Errorf("spec version \"0.0.0\" is invalid, supported versions: v3, v4"),
 		},
 	}
 
@@ -1160,7 +1172,72 @@ func Test_Scenario10(t *testing.T) {
 
 	err = spec.ValidateDAG(dag)
 	require.Error(t, err)
-	assert.Equal(t, err.Error(), "invalid DAG, too many sources")
+	assert.Equal(t, err.Error(), "invalid DAG, function \"function2\" has no input stream")
+}
+
+// Scenario 11 - DAG with two sources merged into one function and chained functions
+// src[0] → fn[0] → fn[1] → dst
+// src[1] ↗
+func Test_Scenario11(t *testing.T) {
+	spec := ir.DeploymentSpec{
+		Definition: ir.DefinitionSpec{
+			Metadata: ir.MetadataSpec{SpecVersion: ir.LatestSpecVersion},
+		},
+	}
+
+	require.NoError(t, spec.AddSource(
+		&ir.ConnectorSpec{
+			UUID:       "1",
+			Name:       "orders",
+			PluginName: "postgres",
+			PluginType: ir.PluginSource,
+		},
+	))
+
+	require.NoError(t, spec.AddSource(
+		&ir.ConnectorSpec{
+			UUID:       "2",
+			Name:       "customers",
+			PluginName: "postgres",
+			PluginType: ir.PluginSource,
+		},
+	))
+
+	require.NoError(t, spec.AddFunction(
+		&ir.FunctionSpec{
+			UUID: "3",
+			Name: "join",
+		},
+	))
+
+	require.NoError(t, spec.AddFunction(
+		&ir.FunctionSpec{
+			UUID: "4",
+			Name: "enrich",
+		},
+	))
+
+	require.NoError(t, spec.AddDestination(
+		&ir.ConnectorSpec{
+			UUID:       "5",
+			Name:       "warehouse",
+			PluginName: "postgres",
+			PluginType: ir.PluginDestination,
+		},
+	))
+
+	for _, s := range []ir.StreamSpec{
+		{UUID: "1_3", Name: "my_stream1", FromUUID: "1", ToUUID: "3"},
+		{UUID: "2_3", Name: "my_stream2", FromUUID: "2", ToUUID: "3"},
+		{UUID: "3_4", Name: "my_stream3", FromUUID: "3", ToUUID: "4"},
+		{UUID: "4_5", Name: "my_stream4", FromUUID: "4", ToUUID: "5"},
+	} {
+		require.NoError(t, spec.AddStream(&s))
+	}
+
+	dag, err := spec.BuildDAG()
+	require.NoError(t, err)
+	require.NoError(t, spec.ValidateDAG(dag))
 }
 
 func Test_ValidateDAG(t *testing.T) {
@@ -1190,10 +1267,11 @@ func Test_ValidateDAG(t *testing.T) {
 			wantError: fmt.Errorf("invalid DAG, no sources found"),
 		},
 		{
-			name: "too many sources",
+			name: "multiple sources",
 			setup: func(t *testing.T) *dag.DAG {
 				t.Helper()
 
+				src1, src2, dest := uuid.New().String(), uuid.New().String(), uuid.New().String()
 				spec := ir.DeploymentSpec{
 					Definition: ir.DefinitionSpec{
 						Metadata: ir.MetadataSpec{
@@ -1202,13 +1280,161 @@ func Test_ValidateDAG(t *testing.T) {
 					},
 					Connectors: []ir.ConnectorSpec{
 						{
-							UUID:       uuid.New().String(),
+							UUID:       src1,
 							PluginType: ir.PluginSource,
 						},
 						{
-							UUID:       uuid.New().String(),
+							UUID:       src2,
 							PluginType: ir.PluginSource,
 						},
+						{
+							UUID:       dest,
+							PluginType: ir.PluginDestination,
+						},
+					},
+					Streams: []ir.StreamSpec{
+						{
+							UUID:     uuid.New().String(),
+							FromUUID: src1,
+							ToUUID:   dest,
+						},
+						{
+							UUID:     uuid.New().String(),
+							FromUUID: src2,
+							ToUUID:   dest,
+						},
+					},
+				}
+
+				dag, err := spec.BuildDAG()
+				require.NoError(t, err)
+
+				return dag
+			},
+		},
+		{
+			name: "orphan source",
+			setup: func(t *testing.T) *dag.DAG {
+				t.Helper()
+
+				spec := ir.DeploymentSpec{
+					Definition: ir.DefinitionSpec{
+						Metadata: ir.MetadataSpec{
+							SpecVersion: ir.SpecVersion_v3,
+						},
+					},
+					Connectors: []ir.ConnectorSpec{
+						{
+							UUID:       "1",
+							Name:       "pg",
+							PluginType: ir.PluginSource,
+						},
+						{
+							UUID:       "2",
+							Name:       "unused",
+							PluginType: ir.PluginSource,
+						},
+						{
+							UUID:       "3",
+							Name:       "s3",
+							PluginType: ir.PluginDestination,
+						},
+					},
+					Streams: []ir.StreamSpec{
+						{
+							UUID:     "1_3",
+							FromUUID: "1",
+							ToUUID:   "3",
+						},
+					},
+				}
+
+				dag, err := spec.BuildDAG()
+				require.NoError(t, err)
+
+				return dag
+			},
+			wantError: fmt.Errorf("invalid DAG, source \"unused\" is not connected to any resource"),
+		},
+		{
+			name: "destination with outgoing stream",
+			setup: func(t *testing.T) *dag.DAG {
+				t.Helper()
+
+				spec := ir.DeploymentSpec{
+					Definition: ir.DefinitionSpec{
+						Metadata: ir.MetadataSpec{
+							SpecVersion: ir.SpecVersion_v3,
+						},
+					},
+					Connectors: []ir.ConnectorSpec{
+						{
+							UUID:       "1",
+							Name:       "pg",
+							PluginType: ir.PluginSource,
+						},
+						{
+							UUID:       "2",
+							Name:       "s3",
+							PluginType: ir.PluginDestination,
+						},
+					},
+					Functions: []ir.FunctionSpec{
+						{
+							UUID: "3",
+							Name: "anonymize",
+						},
+					},
+					Streams: []ir.StreamSpec{
+						{
+							UUID:     "1_2",
+							FromUUID: "1",
+							ToUUID:   "2",
+						},
+						{
+							UUID:     "2_3",
+							FromUUID: "2",
+							ToUUID:   "3",
+						},
+					},
+				}
+
+				dag, err := spec.BuildDAG()
+				require.NoError(t, err)
+
+				return dag
+			},
+			wantError: fmt.Errorf("invalid DAG, destination \"s3\" cannot stream records"),
+		},
+		{
+			name: "source with incoming stream",
+			setup: func(t *testing.T) *dag.DAG {
+				t.Helper()
+
+				spec := ir.DeploymentSpec{
+					Definition: ir.DefinitionSpec{
+						Metadata: ir.MetadataSpec{
+							SpecVersion: ir.SpecVersion_v3,
+						},
+					},
+					Connectors: []ir.ConnectorSpec{
+						{
+							UUID:       "1",
+							Name:       "pg",
+							PluginType: ir.PluginSource,
+						},
+						{
+							UUID:       "2",
+							Name:       "mysql",
+							PluginType: ir.PluginSource,
+						},
+					},
+					Streams: []ir.StreamSpec{
+						{
+							UUID:     "1_2",
+							FromUUID: "1",
+							ToUUID:   "2",
+						},
 					},
 				}
 
@@ -1217,7 +1443,7 @@ func Test_ValidateDAG(t *testing.T) {
 
 				return dag
 			},
-			wantError: fmt.Errorf("invalid DAG, too many sources"),
+			wantError: fmt.Errorf("invalid DAG, source \"mysql\" cannot receive records"),
 		},
 		{
 			name: "only one source",
@@ -1242,7 +1468,7 @@ func Test_ValidateDAG(t *testing.T) {
 
 				return dag
 			},
-			wantError: fmt.Errorf("invalid DAG, there has to be at least one source, at most one function, and zero or more destinations"),
+			wantError: fmt.Errorf("invalid DAG, there has to be at least one source streaming to a function or a destination"),
 		},
 	}
 
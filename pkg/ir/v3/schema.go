@@ -2,34 +2,24 @@ package ir
 
 import (
 	_ "embed"
-	"encoding/json"
 
 	"github.com/meroxa/turbine-core/v2/pkg/ir"
-	"github.com/santhosh-tekuri/jsonschema/v5"
 )
 
 //go:embed schema.json
 var turbineIRSchema string
 
-func ValidateSpec(spec []byte, specVersion string) error {
-	err := ir.ValidateSpecVersion(specVersion)
-	if err != nil {
-		return err
-	}
-
-	sch, err := jsonschema.CompileString("turbine.ir.schema.json", turbineIRSchema)
-	if err != nil {
-		return err
-	}
-
-	var v interface{}
-	if err := json.Unmarshal(spec, &v); err != nil {
-		return err
-	}
+var schema = ir.Schema{
+	Source:   turbineIRSchema,
+	Versions: []string{ir.SpecVersion_v3},
+}
 
-	if err := sch.Validate(v); err != nil {
-		return ir.NewSpecValidationError(err)
-	}
+// Validate reports every schema and DAG violation of the spec instead of
+// only the first one.
+func Validate(spec []byte, specVersion string) *ir.ValidationReport {
+	return schema.Validate(spec, specVersion)
+}
 
-	return nil
+func ValidateSpec(spec []byte, specVersion string) error {
+	return schema.ValidateSpec(spec, specVersion)
 }
@@ -3,6 +3,7 @@ package ir
 import (
 	"testing"
 
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
 	"github.com/stretchr/testify/require"
 )
 
@@ -440,7 +441,6 @@ func Test_ValidSpec(t *testing.T) {
 					}
 				}
 			}`,
-			err: "\"/functions\" field fails /properties/functions/maxItems validation: maximum 1 items required, but found 2 items",
 		},
 		{
 			desc:        "maximum spec",
@@ -547,3 +547,82 @@ func Test_ValidSpec(t *testing.T) {
 		})
 	}
 }
+
+func Test_Validate(t *testing.T) {
+	spec := `{
+		"connectors": [
+			{
+				"uuid": "68dde1cc-3a56-4a2a-993e-bfe49d526d07",
+				"name": "my_source",
+				"plugin_type": "sink"
+			}
+		],
+		"definition": {
+			"git_sha": "83e7c39d83fe4cc04a404182dc30b8d9bed2537b",
+			"metadata": {
+				"turbine": {
+					"language": "golang"
+				},
+				"spec_version": "v3"
+			}
+		}
+	}`
+
+	report := Validate([]byte(spec), "v3")
+	require.True(t, report.HasErrors())
+
+	got := map[string]ir.Violation{}
+	for _, v := range report.Violations {
+		got[v.Code+" "+v.Pointer] = v
+	}
+
+	require.Contains(t, got, "schema.required /connectors/0")
+	require.Equal(t, "my_source", got["schema.required /connectors/0"].Resource)
+	require.Contains(t, got, "schema.enum /connectors/0/plugin_type")
+	require.Contains(t, got, "schema.required /definition/metadata/turbine")
+	require.Contains(t, got, "dag.no_streams ")
+}
+
+func Test_Validate_PlaintextSecret(t *testing.T) {
+	spec := `{
+		"secrets": {"key": "valuesecret"},
+		"connectors": [],
+		"definition": {
+			"git_sha": "83e7c39d83fe4cc04a404182dc30b8d9bed2537b",
+			"metadata": {
+				"turbine": {"language": "golang", "version": "1"},
+				"spec_version": "v3"
+			}
+		}
+	}`
+
+	report := Validate([]byte(spec), "v3")
+
+	got := map[string]ir.Violation{}
+	for _, v := range report.Violations {
+		got[v.Code+" "+v.Pointer] = v
+	}
+
+	require.Contains(t, got, "spec.plaintext_secret /secrets/key")
+	require.Equal(t, ir.SeverityWarning, got["spec.plaintext_secret /secrets/key"].Severity)
+	// The rest of the spec is still validated.
+	require.Contains(t, got, "dag.no_sources ")
+}
+
+func Test_Validate_UnsupportedVersion(t *testing.T) {
+	report := Validate([]byte(`{}`), "0.1.1")
+	require.Equal(t, []ir.Violation{{
+		Pointer:  "/definition/metadata/spec_version",
+		Severity: ir.SeverityError,
+		Code:     ir.CodeInvalidVersion,
+		Message:  "spec version \"0.1.1\" is invalid, supported versions: v3, v4",
+	}}, report.Violations)
+
+	report = Validate([]byte(`{}`), "v4")
+	require.Equal(t, []ir.Violation{{
+		Pointer:  "/definition/metadata/spec_version",
+		Severity: ir.SeverityError,
+		Code:     ir.CodeInvalidVersion,
+		Message:  "spec version \"v4\" is not supported by this schema, supported versions: v3",
+	}}, report.Violations)
+}
@@ -0,0 +1,25 @@
+package ir
+
+import (
+	_ "embed"
+
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+)
+
+//go:embed schema.json
+var turbineIRSchema string
+
+var schema = ir.Schema{
+	Source:   turbineIRSchema,
+	Versions: []string{ir.SpecVersion_v4},
+}
+
+// Validate reports every schema and DAG violation of the spec instead of
+// only the first one.
+func Validate(spec []byte, specVersion string) *ir.ValidationReport {
+	return schema.Validate(spec, specVersion)
+}
+
+func ValidateSpec(spec []byte, specVersion string) error {
+	return schema.ValidateSpec(spec, specVersion)
+}
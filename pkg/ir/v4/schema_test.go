@@ -0,0 +1,229 @@
+package ir
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidSpec(t *testing.T) {
+	testCases := []struct {
+		desc        string
+		specVersion string
+		spec        string
+		err         string
+	}{
+		{
+			desc:        "empty spec",
+			specVersion: "v4",
+			spec:        `{}`,
+			err:         "\"\" field fails /required validation: missing properties: 'connectors', 'definition'",
+		},
+		{
+			desc:        "unsupported spec version",
+			specVersion: "0.2.0",
+			spec:        `{}`,
+			err:         "spec version \"0.2.0\" is invalid, supported versions: v3, v4",
+		},
+		{
+			desc:        "spec version of another schema",
+			specVersion: "v3",
+			spec:        `{}`,
+			err:         "spec version \"v3\" is not supported by this schema, supported versions: v4",
+		},
+		{
+			desc:        "wrong spec version in definition",
+			specVersion: "v4",
+			spec: `{
+						"connectors": [],
+						"definition": {
+							"git_sha": "83e7c39d83fe4cc04a404182dc30b8d9bed2537b",
+							"metadata": {
+								"turbine": {
+									"language": "golang",
+									"version": "0.19"
+								},
+								"spec_version": "v3"
+							}
+						}
+					}`,
+			err: "\"/definition/metadata/spec_version\" field fails /properties/definition/properties/metadata/properties/spec_version/const validation: value must be \"v4\"",
+		},
+		{
+			desc:        "connector without uuid",
+			specVersion: "v4",
+			spec: `{
+						"connectors": [
+							{
+								"uuid": "13ae6f06-9fd0-4395-906e-9bba9a76ffc0",
+								"name": "my_source",
+								"plugin_type": "source",
+								"plugin_name": "postgres"
+							},
+							{
+								"name": "my_destination",
+								"plugin_type": "destination",
+								"plugin_name": "postgres"
+							}
+						],
+						"definition": {
+							"git_sha": "83e7c39d83fe4cc04a404182dc30b8d9bed2537b",
+							"metadata": {
+								"turbine": {
+									"language": "golang",
+									"version": "0.19"
+								},
+								"spec_version": "v4"
+							}
+						}
+					}`,
+			err: "\"/connectors/1\" field fails /properties/connectors/items/required validation: missing properties: 'uuid'",
+		},
+		{
+			desc:        "spec with multiple sources and functions",
+			specVersion: "v4",
+			spec: `{
+						"connectors": [
+							{
+								"uuid": "13ae6f06-9fd0-4395-906e-9bba9a76ffc0",
+								"name": "orders",
+								"plugin_type": "source",
+								"plugin_name": "postgres"
+							},
+							{
+								"uuid": "2c6b3b6c-2fd5-4d2c-9c59-56f1f6a4f0e1",
+								"name": "customers",
+								"plugin_type": "source",
+								"plugin_name": "postgres"
+							},
+							{
+								"uuid": "68dde1cc-3a56-4a2a-993e-bfe49d526d07",
+								"name": "warehouse",
+								"plugin_type": "destination",
+								"plugin_name": "postgres"
+							}
+						],
+						"functions": [
+							{
+								"uuid": "d07f1a3d-f7e2-4495-a8fe-df46bef38a2b",
+								"name": "join",
+								"image": "ftorres/join:1"
+							},
+							{
+								"uuid": "0d7f1a24-f7e2-4495-a8fe-df46bef32345",
+								"name": "enrich",
+								"image": "ftorres/enrich:1"
+							}
+						],
+						"streams": [
+							{
+								"uuid": "1",
+								"name": "orders_join",
+								"from_uuid": "13ae6f06-9fd0-4395-906e-9bba9a76ffc0",
+								"to_uuid": "d07f1a3d-f7e2-4495-a8fe-df46bef38a2b"
+							},
+							{
+								"uuid": "2",
+								"name": "customers_join",
+								"from_uuid": "2c6b3b6c-2fd5-4d2c-9c59-56f1f6a4f0e1",
+								"to_uuid": "d07f1a3d-f7e2-4495-a8fe-df46bef38a2b"
+							},
+							{
+								"uuid": "3",
+								"name": "join_enrich",
+								"from_uuid": "d07f1a3d-f7e2-4495-a8fe-df46bef38a2b",
+								"to_uuid": "0d7f1a24-f7e2-4495-a8fe-df46bef32345"
+							},
+							{
+								"uuid": "4",
+								"name": "enrich_warehouse",
+								"from_uuid": "0d7f1a24-f7e2-4495-a8fe-df46bef32345",
+								"to_uuid": "68dde1cc-3a56-4a2a-993e-bfe49d526d07"
+							}
+						],
+						"definition": {
+							"git_sha": "b1537986d46bcd810960696d1e6df739e7bcc592",
+							"metadata": {
+								"turbine": {
+									"version": "1.5.1",
+									"language": "golang"
+								},
+								"spec_version": "v4"
+							}
+						}
+					}`,
+		},
+		{
+			desc:        "declared secret",
+			specVersion: "v4",
+			spec: `{
+				"secrets": {
+					"API_KEY": {"description": "key of the anonymization API"}
+				},
+				"connectors": [],
+				"definition": {
+					"git_sha": "b1537986d46bcd810960696d1e6df739e7bcc592",
+					"metadata": {
+						"turbine": {
+							"version": "1.5.1",
+							"language": "py"
+						},
+						"spec_version": "v4"
+					}
+				}
+			}`,
+		},
+		{
+			desc:        "plaintext secret",
+			specVersion: "v4",
+			spec: `{
+				"secrets": {
+					"API_KEY": "token"
+				},
+				"connectors": [],
+				"definition": {
+					"git_sha": "b1537986d46bcd810960696d1e6df739e7bcc592",
+					"metadata": {
+						"turbine": {
+							"version": "1.5.1",
+							"language": "py"
+						},
+						"spec_version": "v4"
+					}
+				}
+			}`,
+			err: "\"/secrets/API_KEY\" field fails /properties/secrets/additionalProperties/type validation: expected object, but got string",
+		},
+		{
+			desc:        "invalid secret name",
+			specVersion: "v4",
+			spec: `{
+				"secrets": {
+					"api-key": {}
+				},
+				"connectors": [],
+				"definition": {
+					"git_sha": "b1537986d46bcd810960696d1e6df739e7bcc592",
+					"metadata": {
+						"turbine": {
+							"version": "1.5.1",
+							"language": "py"
+						},
+						"spec_version": "v4"
+					}
+				}
+			}`,
+			err: "\"/secrets/api-key\" field fails /properties/secrets/propertyNames/pattern validation: does not match pattern '^[A-Za-z_][A-Za-z0-9_]*$'",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := ValidateSpec([]byte(tc.spec), tc.specVersion)
+			if tc.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.Equal(t, tc.err, err.Error())
+			}
+		})
+	}
+}
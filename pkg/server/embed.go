@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"net"
+
+	"github.com/meroxa/turbine-core/v2/pkg/client"
+	"github.com/meroxa/turbine-core/v2/proto/turbine/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// embeddedBufferSize is the size of the in-memory connection buffer.
+const embeddedBufferSize = 1 << 20
+
+var _ client.Client = (*EmbeddedClient)(nil)
+
+// EmbeddedClient is a client of a service served in-process over an
+// in-memory connection, so that no port is opened. Requests still go through
+// gRPC, with the same validation, status codes and streaming as over TCP.
+type EmbeddedClient struct {
+	*client.TurbineClient
+
+	server  *grpc.Server
+	service turbinev2.ServiceServer
+}
+
+// Embed serves a service in-process and returns a client for it, which can
+// be used wherever a client.Client is expected. Closing the client stops the
+// service.
+func Embed(ctx context.Context, svc turbinev2.ServiceServer) (*EmbeddedClient, error) {
+	lis := bufconn.Listen(embeddedBufferSize)
+
+	s, _ := newGRPCServer(svc)
+	go func() {
+		// Serve only returns once the server is stopped.
+		_ = s.Serve(lis)
+	}()
+
+	conn, err := grpc.DialContext(
+		ctx,
+		"passthrough:///embedded",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		s.Stop()
+		return nil, err
+	}
+
+	return &EmbeddedClient{
+		TurbineClient: &client.TurbineClient{
+			ClientConn:    conn,
+			ServiceClient: turbinev2.NewServiceClient(conn),
+		},
+		server:  s,
+		service: svc,
+	}, nil
+}
+
+// EmbedRunService serves a new RunService in-process, see Embed.
+func EmbedRunService(ctx context.Context) (*EmbeddedClient, error) {
+	return Embed(ctx, NewRunService())
+}
+
+// EmbedSpecBuilderService serves a new SpecBuilderService in-process, see Embed.
+func EmbedSpecBuilderService(ctx context.Context) (*EmbeddedClient, error) {
+	return Embed(ctx, NewSpecBuilderService())
+}
+
+func (c *EmbeddedClient) Close() {
+	c.TurbineClient.Close()
+	c.server.Stop()
+	_ = closeService(c.service)
+}
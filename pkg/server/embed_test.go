@@ -0,0 +1,119 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/meroxa/turbine-core/v2/pkg/client"
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
+	"github.com/meroxa/turbine-core/v2/proto/turbine/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestEmbedSpecBuilderService(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := EmbedSpecBuilderService(ctx)
+	require.NoError(t, err)
+
+	var _ client.Client = c
+	_, err = c.Init(ctx, &turbinev2.InitRequest{
+		AppName:        "embedded",
+		ConfigFilePath: "path/to/app",
+		Language:       turbinev2.Language_GOLANG,
+		GitSHA:         "gitsha",
+		TurbineVersion: "0.1.0",
+	})
+	require.NoError(t, err)
+
+	src, err := c.AddSource(ctx, &turbinev2.AddSourceRequest{
+		Name:   "source",
+		Plugin: &turbinev2.Plugin{Name: "postgres"},
+	})
+	require.NoError(t, err)
+
+	dst, err := c.AddDestination(ctx, &turbinev2.AddDestinationRequest{
+		Name:   "destination",
+		Plugin: &turbinev2.Plugin{Name: "s3"},
+	})
+	require.NoError(t, err)
+
+	_, err = c.WriteRecords(ctx, &turbinev2.WriteRecordsRequest{
+		DestinationID: dst.Id,
+		StreamRecords: &turbinev2.StreamRecords{StreamName: src.StreamName},
+	})
+	require.NoError(t, err)
+
+	res, err := c.GetSpec(ctx, &turbinev2.GetSpecRequest{})
+	require.NoError(t, err)
+
+	spec, err := ir.Unmarshal(res.Spec)
+	require.NoError(t, err)
+	assert.Len(t, spec.Connectors, 2)
+	assert.Len(t, spec.Streams, 1)
+
+	// Validation errors reach the client as they would over TCP.
+	_, err = c.AddSource(ctx, &turbinev2.AddSourceRequest{})
+	require.ErrorContains(t, err, "invalid AddSourceRequest.Name")
+
+	c.Close()
+	_, err = c.AddSource(ctx, &turbinev2.AddSourceRequest{Name: "source"})
+	require.Equal(t, codes.Canceled, status.Code(err))
+}
+
+func TestEmbedRunService(t *testing.T) {
+	ctx := context.Background()
+	tempdir := t.TempDir()
+
+	fixture := append(append([]byte("["), testJSONRecord(t)...), ']')
+	require.NoError(t, os.WriteFile(path.Join(tempdir, "fixture.json"), fixture, 0o644))
+	require.NoError(t, os.WriteFile(path.Join(tempdir, "app.json"), []byte(`{
+		"name": "embedded",
+		"language": "golang",
+		"fixtures": {"source": "fixture.json"},
+		"destinations": {"destination": {"type": "memory"}}
+	}`), 0o644))
+
+	svc := NewRunService()
+	c, err := Embed(ctx, svc)
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Init(ctx, &turbinev2.InitRequest{
+		AppName:        "embedded",
+		ConfigFilePath: tempdir,
+		Language:       turbinev2.Language_GOLANG,
+	})
+	require.NoError(t, err)
+
+	stream, err := c.ReadRecordsStream(ctx, &turbinev2.ReadRecordsStreamRequest{SourceStream: "source"})
+	require.NoError(t, err)
+
+	var batches []*turbinev2.StreamRecords
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		batches = append(batches, resp.StreamRecords)
+	}
+	require.Len(t, batches, 1)
+
+	_, err = c.WriteRecords(ctx, &turbinev2.WriteRecordsRequest{
+		DestinationID: "destination",
+		StreamRecords: batches[0],
+	})
+	require.NoError(t, err)
+
+	written, ok := svc.WrittenRecords("destination")
+	require.True(t, ok)
+	require.Len(t, written, 1)
+}
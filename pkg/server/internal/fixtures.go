@@ -1,60 +1,138 @@
 package internal
 
 import (
+	"bufio"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"text/tabwriter"
 
 	"github.com/conduitio/conduit-commons/opencdc"
 	"github.com/conduitio/conduit-commons/proto/opencdc/v1"
-	"github.com/meroxa/turbine-core/v2/proto/turbine/v2"
 )
 
-func ReadFixture(ctx context.Context, file string) ([]*opencdcv1.Record, error) {
-	b, err := os.ReadFile(file)
+// ReadFixture reads every record of a fixture file, see OpenFixture.
+func ReadFixture(ctx context.Context, file, format string) ([]*opencdcv1.Record, error) {
+	fr, err := OpenFixture(file, format)
 	if err != nil {
 		return nil, err
 	}
+	defer fr.Close()
 
-	var fixtureRecords []opencdc.Record
+	return ReadAll(ctx, fr)
+}
+
+// ReadAll reads every remaining record of a fixture.
+func ReadAll(ctx context.Context, r *FixtureReader) ([]*opencdcv1.Record, error) {
+	protoRecords := []*opencdcv1.Record{}
+	for {
+		batch, err := r.Next(ctx, 0, 0)
+		if errors.Is(err, io.EOF) {
+			return protoRecords, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		protoRecords = append(protoRecords, batch...)
+	}
+}
+
+// FixtureReader decodes the records of a fixture file one at a time, so that
+// the whole file never has to be held in memory.
+type FixtureReader struct {
+	f   *os.File
+	dec recordDecoder
+}
+
+// recordDecoder decodes the records of a fixture format.
+type recordDecoder interface {
+	// Decode returns the next record and the size of its encoded form,
+	// or io.EOF once every record was read.
+	Decode() (opencdc.Record, int, error)
+}
+
+// OpenFixture opens a fixture file of the given format, which is detected
+// from the file when empty.
+func OpenFixture(file, format string) (*FixtureReader, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+
+	r := bufio.NewReader(f)
+	if format == "" {
+		if format, err = detectFixtureFormat(file, r); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
 
-	if err := json.Unmarshal(b, &fixtureRecords); err != nil {
+	dec, err := newRecordDecoder(format, r)
+	if err != nil {
+		f.Close()
 		return nil, err
 	}
 
-	protoRecords := make([]*opencdcv1.Record, len(fixtureRecords))
+	return &FixtureReader{f: f, dec: dec}, nil
+}
+
+// Next returns the next batch of records, holding at most maxRecords records
+// and ending once the encoded size of its records reaches maxBytes. A zero
+// limit is ignored. io.EOF is returned once every record was read.
+func (r *FixtureReader) Next(ctx context.Context, maxRecords, maxBytes int) ([]*opencdcv1.Record, error) {
+	var (
+		batch []*opencdcv1.Record
+		size  int
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		record, n, err := r.dec.Decode()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
 
-	for i, r := range fixtureRecords {
-		protoRecords[i] = &opencdcv1.Record{}
-		if err := r.ToProto(protoRecords[i]); err != nil {
+		protoRecord := &opencdcv1.Record{}
+		if err := record.ToProto(protoRecord); err != nil {
 			return nil, err
 		}
+		batch = append(batch, protoRecord)
+
+		size += n
+		if (maxRecords > 0 && len(batch) >= maxRecords) || (maxBytes > 0 && size >= maxBytes) {
+			return batch, nil
+		}
 	}
 
-	return protoRecords, nil
+	if len(batch) > 0 {
+		return batch, nil
+	}
+	return nil, io.EOF
 }
 
-func PrintRecords(name string, sr *turbinev2.StreamRecords) {
+func (r *FixtureReader) Close() error {
+	return r.f.Close()
+}
+
+func PrintRecords(name string, records []opencdc.Record) {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', tabwriter.AlignRight|tabwriter.Debug)
 	fmt.Fprintf(w, "Destination %s\n", name)
 	fmt.Fprintf(w, "----------------------\n")
 	fmt.Fprintln(w, "index\trecord")
 	fmt.Fprintln(w, "----\t----")
 
-	for i, proto := range sr.Records {
-		var r opencdc.Record
-
-		if err := r.FromProto(proto); err != nil {
-			fmt.Fprintf(w, "%d\t%s\n", i, fmt.Sprintf("failed to render, error: %s", err.Error()))
-		} else {
-			fmt.Fprintf(w, "%d\t%s\n", i, string(r.Bytes()))
-		}
-
+	for i, r := range records {
+		fmt.Fprintf(w, "%d\t%s\n", i, string(r.Bytes()))
 		fmt.Fprintln(w, "----\t----")
 	}
 
-	fmt.Fprintf(w, "records written\t%d\n", len(sr.Records))
+	fmt.Fprintf(w, "records written\t%d\n", len(records))
 	w.Flush()
 }
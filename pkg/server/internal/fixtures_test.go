@@ -1 +1,117 @@
 package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/meroxa/turbine-core/v2/pkg/app"
+	"github.com/stretchr/testify/require"
+)
+
+const testFixtureRecord = `{"position":"cG9z","operation":"create","metadata":{"postgres.table":"orders"},"key":"a2V5","payload":{"before":null,"after":{"id":1}}}`
+
+func writeFixture(t *testing.T, records int) string {
+	t.Helper()
+
+	items := make([]string, records)
+	for i := range items {
+		items[i] = testFixtureRecord
+	}
+
+	file := filepath.Join(t.TempDir(), "fixture.json")
+	require.NoError(t, os.WriteFile(file, []byte("["+strings.Join(items, ",\n")+"]"), 0o644))
+	return file
+}
+
+func TestFixtureReader_Next(t *testing.T) {
+	tests := []struct {
+		desc       string
+		records    int
+		maxRecords int
+		maxBytes   int
+		wantSizes  []int
+	}{
+		{
+			desc:      "reads everything without limits",
+			records:   5,
+			wantSizes: []int{5},
+		},
+		{
+			desc:       "bounds batches by record count",
+			records:    5,
+			maxRecords: 2,
+			wantSizes:  []int{2, 2, 1},
+		},
+		{
+			desc:      "bounds batches by size",
+			records:   5,
+			maxBytes:  2 * len(testFixtureRecord),
+			wantSizes: []int{2, 2, 1},
+		},
+		{
+			desc:    "empty fixture",
+			records: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			fr, err := OpenFixture(writeFixture(t, tc.records), "")
+			require.NoError(t, err)
+			defer fr.Close()
+
+			var sizes []int
+			for {
+				batch, err := fr.Next(context.Background(), tc.maxRecords, tc.maxBytes)
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				require.NoError(t, err)
+				sizes = append(sizes, len(batch))
+			}
+			require.Equal(t, tc.wantSizes, sizes)
+		})
+	}
+}
+
+func TestFixtureReader_Errors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		content string
+		wantErr string
+	}{
+		{
+			desc:    "not an array",
+			content: testFixtureRecord,
+			wantErr: "fixture must be a JSON array of records, found {",
+		},
+		{
+			desc:    "invalid record",
+			content: "[" + testFixtureRecord + `, {"operation": "unknown"}]`,
+			wantErr: fmt.Sprintf("failed to decode record 1 at offset %d", 1+len(testFixtureRecord)),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), "fixture.json")
+			require.NoError(t, os.WriteFile(file, []byte(tc.content), 0o644))
+
+			_, err := ReadFixture(context.Background(), file, app.FixtureJSON)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestReadFixture(t *testing.T) {
+	records, err := ReadFixture(context.Background(), writeFixture(t, 3), "")
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, "orders", records[0].Metadata["postgres.table"])
+}
@@ -0,0 +1,271 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/hamba/avro/v2/ocf"
+	"github.com/meroxa/turbine-core/v2/pkg/app"
+)
+
+// avroMagic starts every Avro object container file.
+var avroMagic = []byte{'O', 'b', 'j', 1}
+
+// detectFixtureFormat guesses the format of a fixture from its extension,
+// falling back to the first bytes of its content.
+func detectFixtureFormat(file string, r *bufio.Reader) (string, error) {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".jsonl", ".ndjson":
+		return app.FixtureJSONL, nil
+	case ".csv":
+		return app.FixtureCSV, nil
+	case ".avro":
+		return app.FixtureAvro, nil
+	case ".sql":
+		return app.FixtureSQL, nil
+	}
+
+	if head, _ := r.Peek(len(avroMagic)); bytes.Equal(head, avroMagic) {
+		return app.FixtureAvro, nil
+	}
+
+	for i := 1; ; i++ {
+		head, err := r.Peek(i)
+		if err != nil {
+			return "", fmt.Errorf("unable to detect the format of fixture %s", file)
+		}
+		switch c := head[i-1]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			continue
+		case c == '[':
+			return app.FixtureJSON, nil
+		case c == '{':
+			// Conduit file output is one record per line as well.
+			return app.FixtureJSONL, nil
+		default:
+			return "", fmt.Errorf("unable to detect the format of fixture %s", file)
+		}
+	}
+}
+
+func newRecordDecoder(format string, r *bufio.Reader) (recordDecoder, error) {
+	switch format {
+	case app.FixtureJSON:
+		return &jsonArrayDecoder{dec: json.NewDecoder(r)}, nil
+	case app.FixtureJSONL:
+		return &jsonLinesDecoder{r: r}, nil
+	case app.FixtureConduit:
+		return &jsonLinesDecoder{r: r, structure: true}, nil
+	case app.FixtureCSV:
+		return newCSVDecoder(r)
+	case app.FixtureAvro:
+		return newAvroDecoder(r)
+	case app.FixtureSQL:
+		return newSQLDecoder(r)
+	default:
+		return nil, fmt.Errorf("unknown fixture format %q", format)
+	}
+}
+
+// jsonArrayDecoder decodes a JSON array of records.
+type jsonArrayDecoder struct {
+	dec     *json.Decoder
+	started bool
+	index   int
+}
+
+func (d *jsonArrayDecoder) Decode() (opencdc.Record, int, error) {
+	if !d.started {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return opencdc.Record{}, 0, fmt.Errorf("failed to decode fixture at offset %d: %w", d.dec.InputOffset(), err)
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return opencdc.Record{}, 0, fmt.Errorf("fixture must be a JSON array of records, found %v", tok)
+		}
+		d.started = true
+	}
+
+	if !d.dec.More() {
+		return opencdc.Record{}, 0, io.EOF
+	}
+
+	offset := d.dec.InputOffset()
+	var record opencdc.Record
+	if err := d.dec.Decode(&record); err != nil {
+		return opencdc.Record{}, 0, fmt.Errorf("failed to decode record %d at offset %d: %w", d.index, offset, err)
+	}
+	d.index++
+
+	return record, int(d.dec.InputOffset() - offset), nil
+}
+
+// jsonLinesDecoder decodes one JSON record per line, skipping blank lines.
+// When structure is set, raw keys and payloads holding a JSON object are
+// decoded into structured data, as Conduit file destinations write them.
+type jsonLinesDecoder struct {
+	r         *bufio.Reader
+	line      int
+	structure bool
+}
+
+func (d *jsonLinesDecoder) Decode() (opencdc.Record, int, error) {
+	for {
+		b, err := d.r.ReadBytes('\n')
+		if len(b) == 0 && err != nil {
+			if errors.Is(err, io.EOF) {
+				return opencdc.Record{}, 0, io.EOF
+			}
+			return opencdc.Record{}, 0, err
+		}
+		d.line++
+
+		line := bytes.TrimSpace(b)
+		if len(line) == 0 {
+			continue
+		}
+
+		var record opencdc.Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			return opencdc.Record{}, 0, fmt.Errorf("failed to decode record on line %d: %w", d.line, err)
+		}
+		if d.structure {
+			record.Key = structure(record.Key)
+			record.Payload.Before = structure(record.Payload.Before)
+			record.Payload.After = structure(record.Payload.After)
+		}
+		return record, len(b), nil
+	}
+}
+
+// structure decodes raw data holding a JSON object into structured data.
+func structure(d opencdc.Data) opencdc.Data {
+	raw, ok := d.(opencdc.RawData)
+	if !ok {
+		return d
+	}
+
+	var sd opencdc.StructuredData
+	if err := json.Unmarshal(raw, &sd); err != nil || sd == nil {
+		return d
+	}
+	return sd
+}
+
+// csvDecoder decodes one record per row into payload.after, keyed by the
+// column names of the header row. Positions hold the line of the row.
+type csvDecoder struct {
+	r      *csv.Reader
+	header []string
+}
+
+func newCSVDecoder(r io.Reader) (*csvDecoder, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	return &csvDecoder{r: cr, header: header}, nil
+}
+
+func (d *csvDecoder) Decode() (opencdc.Record, int, error) {
+	offset := d.r.InputOffset()
+	row, err := d.r.Read()
+	if errors.Is(err, io.EOF) {
+		return opencdc.Record{}, 0, io.EOF
+	}
+	if err != nil {
+		// csv.ParseError reports the line and column.
+		return opencdc.Record{}, 0, fmt.Errorf("failed to decode CSV fixture: %w", err)
+	}
+
+	line, _ := d.r.FieldPos(0)
+	after := make(opencdc.StructuredData, len(row))
+	for i, v := range row {
+		after[d.header[i]] = v
+	}
+
+	return opencdc.Record{
+		Position:  opencdc.Position(strconv.Itoa(line)),
+		Operation: opencdc.OperationSnapshot,
+		Metadata:  opencdc.Metadata{},
+		Payload:   opencdc.Change{After: after},
+	}, int(d.r.InputOffset() - offset), nil
+}
+
+// avroDecoder decodes the records of an Avro object container file into
+// payload.after. Positions hold the index of the record in the file.
+type avroDecoder struct {
+	dec   *ocf.Decoder
+	index int
+}
+
+func newAvroDecoder(r io.Reader) (*avroDecoder, error) {
+	dec, err := ocf.NewDecoder(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Avro header: %w", err)
+	}
+	return &avroDecoder{dec: dec}, nil
+}
+
+func (d *avroDecoder) Decode() (opencdc.Record, int, error) {
+	if !d.dec.HasNext() {
+		if err := d.dec.Error(); err != nil {
+			return opencdc.Record{}, 0, fmt.Errorf("failed to decode Avro record %d: %w", d.index, err)
+		}
+		return opencdc.Record{}, 0, io.EOF
+	}
+
+	var after map[string]interface{}
+	if err := d.dec.Decode(&after); err != nil {
+		return opencdc.Record{}, 0, fmt.Errorf("failed to decode Avro record %d: %w", d.index, err)
+	}
+
+	record := opencdc.Record{
+		Position:  opencdc.Position(strconv.Itoa(d.index)),
+		Operation: opencdc.OperationSnapshot,
+		Metadata:  opencdc.Metadata{},
+		Payload:   opencdc.Change{After: opencdc.StructuredData(after)},
+	}
+	d.index++
+
+	return record, len(record.Payload.After.Bytes()), nil
+}
+
+// sqlDecoder returns the changes of a Postgres SQL dump, see SQLDump. The
+// statements are replayed when the fixture is opened.
+type sqlDecoder struct {
+	records []opencdc.Record
+}
+
+func newSQLDecoder(r io.Reader) (*sqlDecoder, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	d := NewSQLDump()
+	if err := d.Parse(string(b)); err != nil {
+		return nil, fmt.Errorf("failed to decode SQL fixture: %w", err)
+	}
+	return &sqlDecoder{records: d.Records()}, nil
+}
+
+func (d *sqlDecoder) Decode() (opencdc.Record, int, error) {
+	if len(d.records) == 0 {
+		return opencdc.Record{}, 0, io.EOF
+	}
+
+	record := d.records[0]
+	d.records = d.records[1:]
+	return record, len(record.Bytes()), nil
+}
@@ -0,0 +1,174 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/hamba/avro/v2/ocf"
+	"github.com/meroxa/turbine-core/v2/pkg/app"
+	"github.com/stretchr/testify/require"
+)
+
+func testAvroFixture(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	enc, err := ocf.NewEncoder(`{
+		"type": "record",
+		"name": "order",
+		"fields": [
+			{"name": "id", "type": "long"},
+			{"name": "name", "type": "string"}
+		]
+	}`, &buf)
+	require.NoError(t, err)
+
+	type order struct {
+		ID   int64  `avro:"id"`
+		Name string `avro:"name"`
+	}
+	require.NoError(t, enc.Encode(order{ID: 1, Name: "laptop"}))
+	require.NoError(t, enc.Encode(order{ID: 2, Name: "shoes"}))
+	require.NoError(t, enc.Close())
+
+	return buf.Bytes()
+}
+
+func TestReadFixture_Formats(t *testing.T) {
+	rawAfter := base64.StdEncoding.EncodeToString([]byte(`{"id":1}`))
+
+	tests := []struct {
+		desc      string
+		file      string
+		format    string
+		content   []byte
+		wantAfter []opencdc.Data
+		wantPos   []string
+	}{
+		{
+			desc:      "JSON array detected from content",
+			file:      "fixture.json",
+			content:   []byte(" \n[" + testFixtureRecord + "]"),
+			wantAfter: []opencdc.Data{opencdc.StructuredData{"id": float64(1)}},
+			wantPos:   []string{"pos"},
+		},
+		{
+			desc:      "JSON lines detected from extension",
+			file:      "fixture.jsonl",
+			content:   []byte(testFixtureRecord + "\n\n" + testFixtureRecord + "\n"),
+			wantAfter: []opencdc.Data{opencdc.StructuredData{"id": float64(1)}, opencdc.StructuredData{"id": float64(1)}},
+			wantPos:   []string{"pos", "pos"},
+		},
+		{
+			desc:      "JSON lines detected from content",
+			file:      "demo-cdc.json",
+			content:   []byte(testFixtureRecord),
+			wantAfter: []opencdc.Data{opencdc.StructuredData{"id": float64(1)}},
+			wantPos:   []string{"pos"},
+		},
+		{
+			desc:   "Conduit file output structures raw JSON payloads",
+			file:   "demo-cdc.out",
+			format: app.FixtureConduit,
+			content: []byte(`{"position":"cG9z","operation":"create","metadata":{},"key":null,"payload":{"before":null,"after":"` + rawAfter + `"}}` + "\n" +
+				`{"position":"cG9z","operation":"create","metadata":{},"key":null,"payload":{"before":null,"after":"` + base64.StdEncoding.EncodeToString([]byte("plain")) + `"}}`),
+			wantAfter: []opencdc.Data{opencdc.StructuredData{"id": float64(1)}, opencdc.RawData("plain")},
+			wantPos:   []string{"pos", "pos"},
+		},
+		{
+			desc:    "CSV",
+			file:    "orders.csv",
+			content: []byte("id,name\n1,laptop\n2,\"running, shoes\"\n"),
+			wantAfter: []opencdc.Data{
+				opencdc.StructuredData{"id": "1", "name": "laptop"},
+				opencdc.StructuredData{"id": "2", "name": "running, shoes"},
+			},
+			wantPos: []string{"2", "3"},
+		},
+		{
+			desc:    "Avro detected from content",
+			file:    "orders.bin",
+			content: testAvroFixture(t),
+			wantAfter: []opencdc.Data{
+				opencdc.StructuredData{"id": float64(1), "name": "laptop"},
+				opencdc.StructuredData{"id": float64(2), "name": "shoes"},
+			},
+			wantPos: []string{"0", "1"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), tc.file)
+			require.NoError(t, os.WriteFile(file, tc.content, 0o644))
+
+			records, err := ReadFixture(context.Background(), file, tc.format)
+			require.NoError(t, err)
+			require.Len(t, records, len(tc.wantAfter))
+
+			for i, proto := range records {
+				var r opencdc.Record
+				require.NoError(t, r.FromProto(proto))
+				require.Equal(t, tc.wantAfter[i], r.Payload.After)
+				require.Equal(t, tc.wantPos[i], string(r.Position))
+			}
+		})
+	}
+}
+
+func TestReadFixture_FormatErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		file    string
+		format  string
+		content string
+		wantErr string
+	}{
+		{
+			desc:    "JSON lines report the line",
+			file:    "fixture.jsonl",
+			content: testFixtureRecord + "\n" + `{"operation": "unknown"}`,
+			wantErr: "failed to decode record on line 2",
+		},
+		{
+			desc:    "CSV reports the line",
+			file:    "fixture.csv",
+			content: "id,name\n1,laptop\n2\n",
+			wantErr: "record on line 3: wrong number of fields",
+		},
+		{
+			desc:    "Avro reports a bad header",
+			file:    "fixture.avro",
+			content: "not avro",
+			wantErr: "failed to read Avro header",
+		},
+		{
+			desc:    "unknown format",
+			file:    "fixture.json",
+			format:  "xml",
+			content: "<records/>",
+			wantErr: `unknown fixture format "xml"`,
+		},
+		{
+			desc:    "undetectable format",
+			file:    "fixture.txt",
+			content: "<records/>",
+			wantErr: "unable to detect the format of fixture",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), tc.file)
+			require.NoError(t, os.WriteFile(file, []byte(tc.content), 0o644))
+
+			_, err := ReadFixture(context.Background(), file, tc.format)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
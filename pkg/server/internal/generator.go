@@ -0,0 +1,381 @@
+package internal
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// Field types supported by the fixture generator.
+const (
+	FieldInt       = "int"
+	FieldFloat     = "float"
+	FieldBool      = "bool"
+	FieldString    = "string"
+	FieldEnum      = "enum"
+	FieldUUID      = "uuid"
+	FieldTimestamp = "timestamp"
+	FieldName      = "name"
+	FieldEmail     = "email"
+	FieldAddress   = "address"
+	FieldPhone     = "phone"
+)
+
+// Field describes how the values of a record field are generated. Min and Max
+// bound ints and floats, the length of strings, and the Unix time of
+// timestamps. Values lists the choices of an enum.
+type Field struct {
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Min    float64  `json:"min,omitempty"`
+	Max    float64  `json:"max,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// GeneratorConfig describes the records synthesized by GenerateRecords.
+type GeneratorConfig struct {
+	// Seed makes the generated records reproducible.
+	Seed int64 `json:"seed"`
+	// Count is the number of records to generate.
+	Count int `json:"count"`
+	// Fields is the schema of the generated payloads.
+	Fields []Field `json:"fields"`
+	// Key is the field that identifies a row. It defaults to the first field
+	// and is never changed by updates.
+	Key string `json:"key,omitempty"`
+	// Operations weighs the operations of the generated records, keyed by
+	// their name. It defaults to creates only.
+	Operations map[string]int `json:"operations,omitempty"`
+	// Collection is set as the opencdc.collection metadata when not empty.
+	Collection string `json:"collection,omitempty"`
+}
+
+func (c GeneratorConfig) validate() error {
+	if c.Count < 0 {
+		return fmt.Errorf("record count must not be negative, got %d", c.Count)
+	}
+	if len(c.Fields) == 0 {
+		return fmt.Errorf("at least one field is required")
+	}
+
+	names := make(map[string]bool, len(c.Fields))
+	for _, f := range c.Fields {
+		if f.Name == "" {
+			return fmt.Errorf("field name is required")
+		}
+		if names[f.Name] {
+			return fmt.Errorf("field %q is defined more than once", f.Name)
+		}
+		names[f.Name] = true
+
+		switch f.Type {
+		case FieldInt, FieldFloat, FieldString, FieldTimestamp:
+			if f.Min > f.Max {
+				return fmt.Errorf("field %q has min %v greater than max %v", f.Name, f.Min, f.Max)
+			}
+		case FieldEnum:
+			if len(f.Values) == 0 {
+				return fmt.Errorf("enum field %q requires values", f.Name)
+			}
+		case FieldBool, FieldUUID, FieldName, FieldEmail, FieldAddress, FieldPhone:
+		default:
+			return fmt.Errorf("field %q has unknown type %q", f.Name, f.Type)
+		}
+	}
+
+	if c.Key != "" && !names[c.Key] {
+		return fmt.Errorf("key field %q is not defined", c.Key)
+	}
+
+	for name, weight := range c.Operations {
+		var op opencdc.Operation
+		if err := op.UnmarshalText([]byte(name)); err != nil || name == "" {
+			return fmt.Errorf("unknown operation %q", name)
+		}
+		if weight < 0 {
+			return fmt.Errorf("operation %q has a negative weight", name)
+		}
+	}
+	return nil
+}
+
+// GenerateRecords synthesizes records from a schema. The same config always
+// generates the same records. Updates and deletes only target rows created
+// before and not deleted since, and their before payload holds the row as it
+// was last written, so that the records replay consistently. Updates and
+// deletes fall back to creates while there are no rows.
+func GenerateRecords(cfg GeneratorConfig) ([]opencdc.Record, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		cfg: cfg,
+		rnd: rand.New(rand.NewSource(cfg.Seed)), //nolint:gosec // fixtures need reproducible, not secure, values
+		key: cfg.Key,
+	}
+	if g.key == "" {
+		g.key = cfg.Fields[0].Name
+	}
+	g.ops, g.weights = cfg.operations()
+
+	records := make([]opencdc.Record, 0, cfg.Count)
+	for i := 0; i < cfg.Count; i++ {
+		records = append(records, g.next(i))
+	}
+	return records, nil
+}
+
+// GenerateFixture writes the records synthesized from a schema to a JSON lines
+// fixture file, which ReadFixture can load.
+func GenerateFixture(file string, cfg GeneratorConfig) error {
+	records, err := GenerateRecords(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := WriteRecords(f, records); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// WriteRecords writes records as JSON lines.
+func WriteRecords(w io.Writer, records []opencdc.Record) error {
+	for _, r := range records {
+		if _, err := w.Write(append(r.Bytes(), '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// operations returns the weighted operations in a stable order.
+func (c GeneratorConfig) operations() ([]opencdc.Operation, []int) {
+	if len(c.Operations) == 0 {
+		return []opencdc.Operation{opencdc.OperationCreate}, []int{1}
+	}
+
+	names := make([]string, 0, len(c.Operations))
+	for name, weight := range c.Operations {
+		if weight > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	ops := make([]opencdc.Operation, len(names))
+	weights := make([]int, len(names))
+	for i, name := range names {
+		_ = ops[i].UnmarshalText([]byte(name))
+		weights[i] = c.Operations[name]
+	}
+	return ops, weights
+}
+
+type generator struct {
+	cfg     GeneratorConfig
+	rnd     *rand.Rand
+	key     string
+	ops     []opencdc.Operation
+	weights []int
+
+	// rows holds the keys of the live rows in creation order, so that
+	// picking one is deterministic.
+	rows   []string
+	values map[string]opencdc.StructuredData
+}
+
+func (g *generator) next(i int) opencdc.Record {
+	op := g.operation()
+	if len(g.rows) == 0 && (op == opencdc.OperationUpdate || op == opencdc.OperationDelete) {
+		op = opencdc.OperationCreate
+	}
+
+	r := opencdc.Record{
+		Position:  opencdc.Position(strconv.Itoa(i)),
+		Operation: op,
+		Metadata:  opencdc.Metadata{},
+	}
+	if g.cfg.Collection != "" {
+		r.Metadata.SetCollection(g.cfg.Collection)
+	}
+
+	switch op {
+	case opencdc.OperationCreate, opencdc.OperationSnapshot:
+		after := g.row(i)
+		g.store(after)
+		r.Payload.After = after.Clone()
+		r.Key = opencdc.StructuredData{g.key: after[g.key]}
+	case opencdc.OperationUpdate:
+		idx := g.rnd.Intn(len(g.rows))
+		before := g.values[g.rows[idx]]
+		after := before.Clone().(opencdc.StructuredData)
+		// Change at least one field other than the key.
+		for changed := false; !changed && len(g.cfg.Fields) > 1; {
+			for _, f := range g.cfg.Fields {
+				if f.Name != g.key && g.rnd.Intn(2) == 0 {
+					after[f.Name] = g.value(f, i)
+					changed = true
+				}
+			}
+		}
+		g.values[g.rows[idx]] = after
+		r.Payload.Before = before.Clone()
+		r.Payload.After = after.Clone()
+		r.Key = opencdc.StructuredData{g.key: after[g.key]}
+	case opencdc.OperationDelete:
+		idx := g.rnd.Intn(len(g.rows))
+		before := g.values[g.rows[idx]]
+		delete(g.values, g.rows[idx])
+		g.rows = append(g.rows[:idx], g.rows[idx+1:]...)
+		r.Payload.Before = before.Clone()
+		r.Key = opencdc.StructuredData{g.key: before[g.key]}
+	}
+	return r
+}
+
+func (g *generator) operation() opencdc.Operation {
+	total := 0
+	for _, w := range g.weights {
+		total += w
+	}
+	if total == 0 {
+		return opencdc.OperationCreate
+	}
+
+	n := g.rnd.Intn(total)
+	for i, w := range g.weights {
+		if n < w {
+			return g.ops[i]
+		}
+		n -= w
+	}
+	return g.ops[len(g.ops)-1]
+}
+
+// row generates a new row, whose key is not used by a live row.
+func (g *generator) row(i int) opencdc.StructuredData {
+	row := make(opencdc.StructuredData, len(g.cfg.Fields))
+	for _, f := range g.cfg.Fields {
+		row[f.Name] = g.value(f, i)
+	}
+
+	// Random keys may collide with a live row, retry a few times and fall
+	// back to a key derived from the position.
+	for attempt := 0; g.live(row[g.key]) && attempt < 10; attempt++ {
+		row[g.key] = g.value(g.field(g.key), i)
+	}
+	if g.live(row[g.key]) {
+		row[g.key] = fmt.Sprintf("%v-%d", row[g.key], i)
+	}
+	return row
+}
+
+func (g *generator) live(key interface{}) bool {
+	_, ok := g.values[fmt.Sprint(key)]
+	return ok
+}
+
+func (g *generator) store(row opencdc.StructuredData) {
+	if g.values == nil {
+		g.values = map[string]opencdc.StructuredData{}
+	}
+	k := fmt.Sprint(row[g.key])
+	g.rows = append(g.rows, k)
+	g.values[k] = row
+}
+
+func (g *generator) field(name string) Field {
+	for _, f := range g.cfg.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return Field{}
+}
+
+var (
+	firstNames = []string{"Ada", "Alan", "Barbara", "Dennis", "Edsger", "Frances", "Grace", "Ken", "Linus", "Margaret", "Radia", "Rob"}
+	lastNames  = []string{"Allen", "Hamilton", "Hopper", "Kernighan", "Knuth", "Liskov", "Lovelace", "Perlman", "Pike", "Ritchie", "Thompson", "Turing"}
+	streets    = []string{"Main St", "Oak Ave", "Pine Rd", "Maple Dr", "Cedar Ln", "Elm St", "Market St", "Mission St"}
+	cities     = []string{"Austin", "Boston", "Chicago", "Denver", "Portland", "San Francisco", "Seattle"}
+	domains    = []string{"example.com", "example.net", "example.org"}
+)
+
+const letters = "abcdefghijklmnopqrstuvwxyz"
+
+func (g *generator) value(f Field, i int) interface{} {
+	switch f.Type {
+	case FieldInt:
+		lo, hi := int64(f.Min), int64(f.Max)
+		if lo == 0 && hi == 0 {
+			return int64(i + 1)
+		}
+		return lo + g.rnd.Int63n(hi-lo+1)
+	case FieldFloat:
+		if f.Min == 0 && f.Max == 0 {
+			return g.rnd.Float64()
+		}
+		return f.Min + g.rnd.Float64()*(f.Max-f.Min)
+	case FieldBool:
+		return g.rnd.Intn(2) == 0
+	case FieldString:
+		lo, hi := int(f.Min), int(f.Max)
+		if hi == 0 {
+			lo, hi = 8, 8
+		}
+		b := make([]byte, lo+g.rnd.Intn(hi-lo+1))
+		for j := range b {
+			b[j] = letters[g.rnd.Intn(len(letters))]
+		}
+		return string(b)
+	case FieldEnum:
+		return f.Values[g.rnd.Intn(len(f.Values))]
+	case FieldUUID:
+		b := make([]byte, 16)
+		_, _ = g.rnd.Read(b)
+		b[6] = (b[6] & 0x0f) | 0x40
+		b[8] = (b[8] & 0x3f) | 0x80
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+	case FieldTimestamp:
+		lo, hi := int64(f.Min), int64(f.Max)
+		if lo == 0 && hi == 0 {
+			// 2024-01-01 to 2025-01-01
+			lo, hi = 1704067200, 1735689600
+		}
+		return time.Unix(lo+g.rnd.Int63n(hi-lo+1), 0).UTC().Format(time.RFC3339)
+	case FieldName:
+		return g.pick(firstNames) + " " + g.pick(lastNames)
+	case FieldEmail:
+		return strings.ToLower(g.pick(firstNames)+"."+g.pick(lastNames)) +
+			strconv.Itoa(g.rnd.Intn(100)) + "@" + g.pick(domains)
+	case FieldAddress:
+		return fmt.Sprintf("%d %s, %s", 1+g.rnd.Intn(9999), g.pick(streets), g.pick(cities))
+	case FieldPhone:
+		return fmt.Sprintf("+1-555-%03d-%04d", g.rnd.Intn(1000), g.rnd.Intn(10000))
+	default:
+		return nil
+	}
+}
+
+func (g *generator) pick(values []string) string {
+	return values[g.rnd.Intn(len(values))]
+}
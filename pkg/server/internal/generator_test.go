@@ -0,0 +1,161 @@
+package internal
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/stretchr/testify/require"
+)
+
+func testGeneratorConfig() GeneratorConfig {
+	return GeneratorConfig{
+		Seed:  42,
+		Count: 200,
+		Fields: []Field{
+			{Name: "id", Type: FieldInt},
+			{Name: "category", Type: FieldEnum, Values: []string{"camping", "clothing", "electronics"}},
+			{Name: "stock", Type: FieldInt, Min: 0, Max: 10},
+			{Name: "price", Type: FieldFloat, Min: 1, Max: 100},
+			{Name: "customer_email", Type: FieldEmail},
+			{Name: "shipping_address", Type: FieldAddress},
+			{Name: "created_at", Type: FieldTimestamp},
+		},
+		Operations: map[string]int{"create": 4, "update": 3, "delete": 2, "snapshot": 1},
+		Collection: "orders",
+	}
+}
+
+func TestGenerateRecords_Deterministic(t *testing.T) {
+	cfg := testGeneratorConfig()
+
+	first, err := GenerateRecords(cfg)
+	require.NoError(t, err)
+	second, err := GenerateRecords(cfg)
+	require.NoError(t, err)
+	require.Equal(t, first, second)
+
+	cfg.Seed = 7
+	other, err := GenerateRecords(cfg)
+	require.NoError(t, err)
+	require.NotEqual(t, first, other)
+}
+
+func TestGenerateRecords_Consistent(t *testing.T) {
+	records, err := GenerateRecords(testGeneratorConfig())
+	require.NoError(t, err)
+	require.Len(t, records, 200)
+
+	// Replay the records and check that every before payload matches the
+	// row as it was last written.
+	rows := map[string]opencdc.StructuredData{}
+	ops := map[opencdc.Operation]int{}
+	for i, r := range records {
+		ops[r.Operation]++
+		require.Equal(t, fmt.Sprint(i), string(r.Position))
+		require.Equal(t, "orders", r.Metadata[opencdc.MetadataCollection])
+
+		key := fmt.Sprint(r.Key.(opencdc.StructuredData)["id"])
+		switch r.Operation {
+		case opencdc.OperationCreate, opencdc.OperationSnapshot:
+			require.Nil(t, r.Payload.Before)
+			require.NotContains(t, rows, key)
+			rows[key] = r.Payload.After.(opencdc.StructuredData)
+		case opencdc.OperationUpdate:
+			require.Equal(t, rows[key], r.Payload.Before)
+			require.NotEqual(t, r.Payload.Before, r.Payload.After)
+			rows[key] = r.Payload.After.(opencdc.StructuredData)
+		case opencdc.OperationDelete:
+			require.Equal(t, rows[key], r.Payload.Before)
+			require.Nil(t, r.Payload.After)
+			delete(rows, key)
+		}
+
+		for _, d := range []opencdc.Data{r.Payload.Before, r.Payload.After} {
+			if d == nil {
+				continue
+			}
+			row := d.(opencdc.StructuredData)
+			require.Contains(t, []string{"camping", "clothing", "electronics"}, row["category"])
+			require.GreaterOrEqual(t, row["stock"], int64(0))
+			require.LessOrEqual(t, row["stock"], int64(10))
+			require.GreaterOrEqual(t, row["price"], float64(1))
+			require.LessOrEqual(t, row["price"], float64(100))
+			require.Contains(t, row["customer_email"], "@example.")
+			_, err := time.Parse(time.RFC3339, row["created_at"].(string))
+			require.NoError(t, err)
+		}
+	}
+
+	for _, op := range []opencdc.Operation{
+		opencdc.OperationCreate, opencdc.OperationUpdate, opencdc.OperationDelete, opencdc.OperationSnapshot,
+	} {
+		require.NotZero(t, ops[op], "no %s records generated", op)
+	}
+}
+
+func TestGenerateFixture(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "fixtures", "orders.jsonl")
+	cfg := testGeneratorConfig()
+	cfg.Count = 20
+
+	require.NoError(t, GenerateFixture(file, cfg))
+
+	records, err := ReadFixture(context.Background(), file, "")
+	require.NoError(t, err)
+	require.Len(t, records, 20)
+}
+
+func TestGenerateRecords_Errors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		cfg     GeneratorConfig
+		wantErr string
+	}{
+		{
+			desc:    "no fields",
+			cfg:     GeneratorConfig{Count: 1},
+			wantErr: "at least one field is required",
+		},
+		{
+			desc:    "unknown field type",
+			cfg:     GeneratorConfig{Fields: []Field{{Name: "id", Type: "money"}}},
+			wantErr: `field "id" has unknown type "money"`,
+		},
+		{
+			desc:    "duplicate field",
+			cfg:     GeneratorConfig{Fields: []Field{{Name: "id", Type: FieldInt}, {Name: "id", Type: FieldUUID}}},
+			wantErr: `field "id" is defined more than once`,
+		},
+		{
+			desc:    "inverted range",
+			cfg:     GeneratorConfig{Fields: []Field{{Name: "stock", Type: FieldInt, Min: 10, Max: 1}}},
+			wantErr: `field "stock" has min 10 greater than max 1`,
+		},
+		{
+			desc:    "enum without values",
+			cfg:     GeneratorConfig{Fields: []Field{{Name: "category", Type: FieldEnum}}},
+			wantErr: `enum field "category" requires values`,
+		},
+		{
+			desc:    "unknown key",
+			cfg:     GeneratorConfig{Fields: []Field{{Name: "id", Type: FieldUUID}}, Key: "uuid"},
+			wantErr: `key field "uuid" is not defined`,
+		},
+		{
+			desc:    "unknown operation",
+			cfg:     GeneratorConfig{Fields: []Field{{Name: "id", Type: FieldUUID}}, Operations: map[string]int{"upsert": 1}},
+			wantErr: `unknown operation "upsert"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := GenerateRecords(tc.cfg)
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/meroxa/turbine-core/v2/pkg/app"
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+// Sink receives the records written to a destination during a local run.
+type Sink interface {
+	Write(ctx context.Context, records []opencdc.Record) error
+}
+
+// NewSink returns the sink configured for a destination. Relative paths are
+// resolved against the app path.
+func NewSink(name string, cfg app.Destination, appPath string) (Sink, error) {
+	p := cfg.Path
+	if p != "" && !filepath.IsAbs(p) {
+		p = filepath.Join(appPath, p)
+	}
+
+	switch cfg.Type {
+	case "", app.SinkStdout:
+		return &StdoutSink{name: name}, nil
+	case app.SinkMemory:
+		return &MemorySink{}, nil
+	case app.SinkJSONL:
+		return &JSONLSink{path: p, append: cfg.Append}, nil
+	case app.SinkOpenCDC:
+		return &OpenCDCSink{dir: p, append: cfg.Append}, nil
+	case app.SinkSQLite:
+		table := cfg.Table
+		if table == "" {
+			table = name
+		}
+		return &SQLiteSink{path: p, table: table, append: cfg.Append}, nil
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", cfg.Type)
+	}
+}
+
+// StdoutSink prints the records as a table.
+type StdoutSink struct {
+	name string
+}
+
+func (s *StdoutSink) Write(_ context.Context, records []opencdc.Record) error {
+	PrintRecords(s.name, records)
+	return nil
+}
+
+// MemorySink keeps the records in memory, so that tests can assert on them.
+type MemorySink struct {
+	mu      sync.Mutex
+	records []opencdc.Record
+}
+
+func (s *MemorySink) Write(_ context.Context, records []opencdc.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range records {
+		s.records = append(s.records, r.Clone())
+	}
+	return nil
+}
+
+// Records returns every record written so far.
+func (s *MemorySink) Records() []opencdc.Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]opencdc.Record, len(s.records))
+	copy(out, s.records)
+	return out
+}
+
+// JSONLSink appends every record as a line of JSON to a file. The file is
+// truncated on the first write, unless records of previous runs are kept.
+type JSONLSink struct {
+	mu      sync.Mutex
+	path    string
+	append  bool
+	started bool
+}
+
+func (s *JSONLSink) Write(_ context.Context, records []opencdc.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	flag := os.O_CREATE | os.O_APPEND | os.O_WRONLY
+	if !s.started && !s.append {
+		flag |= os.O_TRUNC
+	}
+	f, err := os.OpenFile(s.path, flag, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	s.started = true
+
+	for _, r := range records {
+		if _, err := f.Write(append(r.Bytes(), '\n')); err != nil {
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// OpenCDCSink writes every record to its own JSON file in a directory. Files
+// are numbered in the order the records were written. The files of previous
+// runs are removed on the first write, unless they are kept, in which case
+// numbering continues after them.
+type OpenCDCSink struct {
+	mu      sync.Mutex
+	dir     string
+	append  bool
+	started bool
+	next    int
+}
+
+func (s *OpenCDCSink) Write(_ context.Context, records []opencdc.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.started {
+		if err := os.MkdirAll(s.dir, 0o755); err != nil {
+			return err
+		}
+		existing, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+		if err != nil {
+			return err
+		}
+		if s.append {
+			// Continue after the highest index, files may have been removed.
+			for _, f := range existing {
+				n, err := strconv.Atoi(strings.TrimSuffix(filepath.Base(f), ".json"))
+				if err == nil && n >= s.next {
+					s.next = n + 1
+				}
+			}
+		} else {
+			for _, f := range existing {
+				if err := os.Remove(f); err != nil {
+					return err
+				}
+			}
+		}
+		s.started = true
+	}
+
+	for _, r := range records {
+		file := filepath.Join(s.dir, fmt.Sprintf("%08d.json", s.next))
+		if err := os.WriteFile(file, r.Bytes(), 0o644); err != nil {
+			return err
+		}
+		s.next++
+	}
+	return nil
+}
+
+// SQLiteSink inserts every record as a row of a SQLite table, which is
+// created when missing. The table is emptied on the first write, unless
+// records of previous runs are kept.
+type SQLiteSink struct {
+	mu      sync.Mutex
+	path    string
+	table   string
+	append  bool
+	started bool
+}
+
+func (s *SQLiteSink) Write(ctx context.Context, records []opencdc.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+
+	db, err := sql.Open("sqlite", s.path)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	table := `"` + strings.ReplaceAll(s.table, `"`, `""`) + `"`
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+table+` (
+		position BLOB,
+		operation TEXT,
+		metadata TEXT,
+		key BLOB,
+		payload_before BLOB,
+		payload_after BLOB
+	)`); err != nil {
+		return fmt.Errorf("failed to create table %s: %w", table, err)
+	}
+	if !s.started && !s.append {
+		if _, err := db.ExecContext(ctx, `DELETE FROM `+table); err != nil {
+			return fmt.Errorf("failed to empty table %s: %w", table, err)
+		}
+	}
+	s.started = true
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck // no-op once committed
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO `+table+
+		` (position, operation, metadata, key, payload_before, payload_after) VALUES (?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, r := range records {
+		metadata, err := json.Marshal(r.Metadata)
+		if err != nil {
+			return err
+		}
+		if _, err := stmt.ExecContext(ctx,
+			[]byte(r.Position),
+			r.Operation.String(),
+			string(metadata),
+			dataBytes(r.Key),
+			dataBytes(r.Payload.Before),
+			dataBytes(r.Payload.After),
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func dataBytes(d opencdc.Data) []byte {
+	if d == nil {
+		return nil
+	}
+	return d.Bytes()
+}
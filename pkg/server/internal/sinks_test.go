@@ -0,0 +1,206 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/meroxa/turbine-core/v2/pkg/app"
+	"github.com/stretchr/testify/require"
+)
+
+func testRecords() []opencdc.Record {
+	return []opencdc.Record{
+		{
+			Position:  opencdc.Position("1"),
+			Operation: opencdc.OperationCreate,
+			Metadata:  opencdc.Metadata{"postgres.table": "orders"},
+			Key:       opencdc.RawData("key-1"),
+			Payload: opencdc.Change{
+				After: opencdc.StructuredData{"id": 1},
+			},
+		},
+		{
+			Position:  opencdc.Position("2"),
+			Operation: opencdc.OperationDelete,
+			Metadata:  opencdc.Metadata{},
+			Key:       opencdc.RawData("key-2"),
+			Payload: opencdc.Change{
+				Before: opencdc.RawData("gone"),
+			},
+		},
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	tests := []struct {
+		desc    string
+		cfg     app.Destination
+		want    Sink
+		wantErr string
+	}{
+		{
+			desc: "defaults to stdout",
+			want: &StdoutSink{name: "dest"},
+		},
+		{
+			desc: "resolves paths against the app",
+			cfg:  app.Destination{Type: app.SinkJSONL, Path: "out.jsonl"},
+			want: &JSONLSink{path: "/app/out.jsonl"},
+		},
+		{
+			desc: "keeps absolute paths",
+			cfg:  app.Destination{Type: app.SinkOpenCDC, Path: "/tmp/records"},
+			want: &OpenCDCSink{dir: "/tmp/records"},
+		},
+		{
+			desc: "defaults the table to the destination name",
+			cfg:  app.Destination{Type: app.SinkSQLite, Path: "app.db"},
+			want: &SQLiteSink{path: "/app/app.db", table: "dest"},
+		},
+		{
+			desc: "keeps the records of previous runs when appending",
+			cfg:  app.Destination{Type: app.SinkJSONL, Path: "out.jsonl", Append: true},
+			want: &JSONLSink{path: "/app/out.jsonl", append: true},
+		},
+		{
+			desc:    "fails on unknown sink types",
+			cfg:     app.Destination{Type: "kafka"},
+			wantErr: `unknown sink type "kafka"`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := NewSink("dest", tc.cfg, "/app")
+			if tc.wantErr != "" {
+				require.EqualError(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestJSONLSink(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "out", "dest.jsonl")
+	sink := &JSONLSink{path: file}
+	records := testRecords()
+
+	require.NoError(t, sink.Write(context.Background(), records[:1]))
+	require.NoError(t, sink.Write(context.Background(), records[1:]))
+
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, string(records[0].Bytes())+"\n"+string(records[1].Bytes())+"\n", string(b))
+
+	// A new run replaces the records of previous runs.
+	require.NoError(t, (&JSONLSink{path: file}).Write(context.Background(), records[1:]))
+	b, err = os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, string(records[1].Bytes())+"\n", string(b))
+
+	// Unless they are kept.
+	require.NoError(t, (&JSONLSink{path: file, append: true}).Write(context.Background(), records[:1]))
+	b, err = os.ReadFile(file)
+	require.NoError(t, err)
+	require.Equal(t, string(records[1].Bytes())+"\n"+string(records[0].Bytes())+"\n", string(b))
+}
+
+func TestOpenCDCSink(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "records")
+	records := testRecords()
+
+	readDir := func() []string {
+		files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		require.NoError(t, err)
+		var got []string
+		for _, f := range files {
+			b, err := os.ReadFile(f)
+			require.NoError(t, err)
+			got = append(got, filepath.Base(f)+" "+string(b))
+		}
+		return got
+	}
+
+	sink := &OpenCDCSink{dir: dir}
+	require.NoError(t, sink.Write(context.Background(), records[:1]))
+	require.NoError(t, sink.Write(context.Background(), records[1:]))
+	require.Equal(t, []string{
+		"00000000.json " + string(records[0].Bytes()),
+		"00000001.json " + string(records[1].Bytes()),
+	}, readDir())
+
+	// A new run replaces the records of previous runs.
+	require.NoError(t, (&OpenCDCSink{dir: dir}).Write(context.Background(), records[1:]))
+	require.Equal(t, []string{
+		"00000000.json " + string(records[1].Bytes()),
+	}, readDir())
+
+	// Unless they are kept.
+	require.NoError(t, (&OpenCDCSink{dir: dir, append: true}).Write(context.Background(), records[:1]))
+	require.Equal(t, []string{
+		"00000000.json " + string(records[1].Bytes()),
+		"00000001.json " + string(records[0].Bytes()),
+	}, readDir())
+
+	// Numbering continues after the highest file, even with gaps.
+	require.NoError(t, os.Remove(filepath.Join(dir, "00000000.json")))
+	require.NoError(t, (&OpenCDCSink{dir: dir, append: true}).Write(context.Background(), records[1:]))
+	require.Equal(t, []string{
+		"00000001.json " + string(records[0].Bytes()),
+		"00000002.json " + string(records[1].Bytes()),
+	}, readDir())
+}
+
+func TestSQLiteSink(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "app.db")
+	sink := &SQLiteSink{path: file, table: "orders"}
+
+	require.NoError(t, sink.Write(context.Background(), testRecords()))
+	require.NoError(t, sink.Write(context.Background(), testRecords()[:1]))
+
+	db, err := sql.Open("sqlite", file)
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT position, operation, metadata, key, payload_before, payload_after FROM orders`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var got [][]string
+	for rows.Next() {
+		var position, operation, metadata, key, before, after sql.NullString
+		require.NoError(t, rows.Scan(&position, &operation, &metadata, &key, &before, &after))
+		got = append(got, []string{position.String, operation.String, metadata.String, key.String, before.String, after.String})
+	}
+	require.NoError(t, rows.Err())
+
+	require.Equal(t, [][]string{
+		{"1", "create", `{"postgres.table":"orders"}`, "key-1", "", `{"id":1}`},
+		{"2", "delete", `{}`, "key-2", "gone", ""},
+		{"1", "create", `{"postgres.table":"orders"}`, "key-1", "", `{"id":1}`},
+	}, got)
+
+	// A new run replaces the records of previous runs.
+	require.NoError(t, (&SQLiteSink{path: file, table: "orders"}).Write(context.Background(), testRecords()[1:]))
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM orders`).Scan(&count))
+	require.Equal(t, 1, count)
+}
+
+func TestMemorySink(t *testing.T) {
+	sink := &MemorySink{}
+	records := testRecords()
+
+	require.NoError(t, sink.Write(context.Background(), records))
+	records[0].Metadata["postgres.table"] = "changed"
+
+	got := sink.Records()
+	require.Len(t, got, 2)
+	require.Equal(t, "orders", got[0].Metadata["postgres.table"])
+}
@@ -0,0 +1,1191 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// MetadataPostgresTable is the metadata key holding the table of a record, as
+// set by the Conduit Postgres connector.
+const MetadataPostgresTable = "postgres.table"
+
+// SQLDump replays the statements of Postgres SQL dumps against an in-memory
+// copy of their tables and records the changes as OpenCDC records, the way
+// the Conduit Postgres connector would capture them. Schemas and data may be
+// split across files, which are parsed in order.
+//
+// CREATE TABLE and ALTER TABLE ... ADD PRIMARY KEY define the tables and their
+// keys. INSERT, COPY ... FROM stdin, UPDATE and DELETE change rows; their
+// values must be literals and their WHERE clauses a conjunction of
+// column = literal or column IN (literals) conditions. Other statements are
+// ignored.
+type SQLDump struct {
+	tables  map[string]*sqlTable
+	order   []string
+	records []opencdc.Record
+	lsn     int
+}
+
+type sqlTable struct {
+	name    string
+	columns []sqlColumn
+	primary []string
+	rows    []opencdc.StructuredData
+}
+
+type sqlColumn struct {
+	name     string
+	typ      string
+	defaultV interface{}
+}
+
+func NewSQLDump() *SQLDump {
+	return &SQLDump{tables: map[string]*sqlTable{}}
+}
+
+// SQLRecords parses SQL dump files in order and returns their changes, see
+// SQLDump. When snapshot is set, the rows left in the tables are returned as
+// snapshot records instead.
+func SQLRecords(snapshot bool, files ...string) ([]opencdc.Record, error) {
+	d := NewSQLDump()
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.Parse(string(b)); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+
+	if snapshot {
+		return d.Snapshot(), nil
+	}
+	return d.Records(), nil
+}
+
+// Parse replays the statements of a SQL dump. Errors report the line of the
+// statement that failed.
+func (d *SQLDump) Parse(src string) error {
+	l := &sqlLexer{src: src, line: 1}
+	for {
+		stmt, err := l.statement()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %w", l.line, err)
+		}
+		if len(stmt) == 0 {
+			continue
+		}
+
+		p := &sqlParser{tokens: stmt}
+		if err := d.exec(p, l); err != nil {
+			return fmt.Errorf("line %d: %w", stmt[0].line, err)
+		}
+	}
+}
+
+// Records returns the change records of every statement parsed so far.
+func (d *SQLDump) Records() []opencdc.Record {
+	out := make([]opencdc.Record, len(d.records))
+	copy(out, d.records)
+	return out
+}
+
+// Snapshot returns a snapshot record for every row left in the tables, in the
+// order the tables were created and the rows inserted.
+func (d *SQLDump) Snapshot() []opencdc.Record {
+	var out []opencdc.Record
+	for _, name := range d.order {
+		t := d.tables[name]
+		for j, row := range t.rows {
+			out = append(out, opencdc.Record{
+				Position:  opencdc.Position(fmt.Sprintf("%s/%d", t.name, j)),
+				Operation: opencdc.OperationSnapshot,
+				Metadata:  opencdc.Metadata{MetadataPostgresTable: t.name},
+				Key:       t.key(row),
+				Payload:   opencdc.Change{After: row.Clone()},
+			})
+		}
+	}
+	return out
+}
+
+func (d *SQLDump) exec(p *sqlParser, l *sqlLexer) error {
+	switch {
+	case p.keyword("create"):
+		// CREATE [UNLOGGED | TEMP | TEMPORARY] TABLE [IF NOT EXISTS]
+		if !p.keyword("unlogged") && !p.keyword("temp") {
+			p.keyword("temporary")
+		}
+		if !p.keyword("table") {
+			return nil
+		}
+		if p.keyword("if") {
+			if !p.keyword("not") || !p.keyword("exists") {
+				return fmt.Errorf("expected IF NOT EXISTS")
+			}
+		}
+		return d.createTable(p)
+	case p.keyword("alter"):
+		if !p.keyword("table") {
+			return nil
+		}
+		return d.alterTable(p)
+	case p.keyword("drop"):
+		if !p.keyword("table") {
+			return nil
+		}
+		p.keyword("if")
+		p.keyword("exists")
+		for {
+			name, err := p.tableName()
+			if err != nil {
+				return err
+			}
+			d.dropTable(name)
+			if !p.punct(",") {
+				return nil
+			}
+		}
+	case p.keyword("insert"):
+		return d.insert(p)
+	case p.keyword("copy"):
+		return d.copyFrom(p, l)
+	case p.keyword("update"):
+		return d.update(p)
+	case p.keyword("delete"):
+		return d.delete(p)
+	default:
+		return nil
+	}
+}
+
+func (d *SQLDump) dropTable(name string) {
+	if _, ok := d.tables[name]; !ok {
+		return
+	}
+	delete(d.tables, name)
+	for i, n := range d.order {
+		if n == name {
+			d.order = append(d.order[:i], d.order[i+1:]...)
+			break
+		}
+	}
+}
+
+func (d *SQLDump) createTable(p *sqlParser) error {
+	name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	if !p.punct("(") {
+		// CREATE TABLE ... AS, OF, PARTITION OF and the like hold no columns.
+		return fmt.Errorf("unsupported CREATE TABLE statement for %s", name)
+	}
+
+	t := &sqlTable{name: tableBaseName(name)}
+	for {
+		switch {
+		case p.keyword("constraint"):
+			if _, err := p.ident(); err != nil {
+				return err
+			}
+			if err := t.constraint(p); err != nil {
+				return err
+			}
+		case p.peekKeyword("primary"), p.peekKeyword("unique"), p.peekKeyword("check"),
+			p.peekKeyword("foreign"), p.peekKeyword("exclude"), p.peekKeyword("like"):
+			if err := t.constraint(p); err != nil {
+				return err
+			}
+		default:
+			if err := t.column(p); err != nil {
+				return err
+			}
+		}
+
+		if p.punct(")") {
+			break
+		}
+		if !p.punct(",") {
+			return fmt.Errorf("expected , or ) in CREATE TABLE %s, found %s", name, p.peek())
+		}
+	}
+
+	d.dropTable(name)
+	d.tables[name] = t
+	d.order = append(d.order, name)
+	return nil
+}
+
+// column parses a column definition and its inline constraints.
+func (t *sqlTable) column(p *sqlParser) error {
+	name, err := p.ident()
+	if err != nil {
+		return err
+	}
+	col := sqlColumn{name: name}
+
+	// The type runs up to the first constraint of the column.
+	var typ []string
+	inType := true
+	for !p.done() && !p.peekPunct(",") && !p.peekPunct(")") {
+		switch {
+		case p.keyword("primary"):
+			if !p.keyword("key") {
+				return fmt.Errorf("expected PRIMARY KEY")
+			}
+			inType = false
+			t.primary = []string{name}
+		case p.keyword("default"):
+			inType = false
+			v, err := p.literal()
+			if err != nil {
+				// Defaults such as nextval(...) can't be evaluated.
+				p.skipExpr()
+				continue
+			}
+			col.defaultV = v
+		case p.peekPunct("("):
+			// Type modifiers and constraint arguments.
+			p.skipGroup()
+		case inType && p.peek().kind == sqlIdent && !isClauseKeyword(p.peek().text):
+			typ = append(typ, p.next().text)
+		default:
+			inType = false
+			p.next()
+		}
+	}
+	col.typ = strings.Join(typ, " ")
+
+	t.columns = append(t.columns, col)
+	return nil
+}
+
+// constraint parses a table constraint and keeps the primary key.
+func (t *sqlTable) constraint(p *sqlParser) error {
+	if p.keyword("primary") {
+		if !p.keyword("key") {
+			return fmt.Errorf("expected PRIMARY KEY")
+		}
+		cols, err := p.identList()
+		if err != nil {
+			return err
+		}
+		t.primary = cols
+	}
+	for !p.done() && !p.peekPunct(",") && !p.peekPunct(")") {
+		p.skipExpr()
+	}
+	return nil
+}
+
+func (d *SQLDump) alterTable(p *sqlParser) error {
+	p.keyword("if")
+	p.keyword("exists")
+	p.keyword("only")
+	name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	t, ok := d.tables[name]
+	if !ok || !p.keyword("add") {
+		return nil
+	}
+	if p.keyword("constraint") {
+		if _, err := p.ident(); err != nil {
+			return err
+		}
+	}
+	return t.constraint(p)
+}
+
+func (d *SQLDump) insert(p *sqlParser) error {
+	if !p.keyword("into") {
+		return fmt.Errorf("expected INSERT INTO")
+	}
+	name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	if p.keyword("as") {
+		if _, err := p.ident(); err != nil {
+			return err
+		}
+	}
+
+	var cols []string
+	if p.peekPunct("(") {
+		if cols, err = p.identList(); err != nil {
+			return err
+		}
+	}
+	t, err := d.table(name, cols)
+	if err != nil {
+		return err
+	}
+	if cols == nil {
+		cols = t.columnNames()
+	}
+
+	if !p.keyword("values") {
+		return fmt.Errorf("only INSERT ... VALUES is supported, found %s", p.peek())
+	}
+	for {
+		if !p.punct("(") {
+			return fmt.Errorf("expected ( after VALUES, found %s", p.peek())
+		}
+		var values []interface{}
+		for {
+			v, err := p.literal()
+			if err != nil {
+				return err
+			}
+			values = append(values, v)
+			if p.punct(")") {
+				break
+			}
+			if !p.punct(",") {
+				return fmt.Errorf("expected , or ) in VALUES, found %s", p.peek())
+			}
+		}
+		if len(values) != len(cols) {
+			return fmt.Errorf("INSERT INTO %s has %d columns but %d values", name, len(cols), len(values))
+		}
+		if err := d.insertRow(t, cols, values); err != nil {
+			return err
+		}
+		if !p.punct(",") {
+			break
+		}
+	}
+
+	if p.keyword("on") {
+		return fmt.Errorf("INSERT ... ON CONFLICT is not supported")
+	}
+	return nil
+}
+
+// copyFrom reads the rows of COPY ... FROM stdin, which follow the statement
+// in the text format of pg_dump.
+func (d *SQLDump) copyFrom(p *sqlParser, l *sqlLexer) error {
+	name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	var cols []string
+	if p.peekPunct("(") {
+		if cols, err = p.identList(); err != nil {
+			return err
+		}
+	}
+	if !p.keyword("from") || !p.keyword("stdin") {
+		return fmt.Errorf("only COPY ... FROM stdin is supported")
+	}
+	t, err := d.table(name, cols)
+	if err != nil {
+		return err
+	}
+	if cols == nil {
+		cols = t.columnNames()
+	}
+
+	for {
+		line, ok := l.rawLine()
+		if !ok {
+			return fmt.Errorf("COPY data for %s is not terminated by \\.", name)
+		}
+		if line == `\.` {
+			return nil
+		}
+
+		fields := strings.Split(line, "\t")
+		if len(fields) != len(cols) {
+			return fmt.Errorf("COPY row on line %d has %d fields, expected %d", l.line-1, len(fields), len(cols))
+		}
+		values := make([]interface{}, len(fields))
+		for i, f := range fields {
+			if f == `\N` {
+				continue
+			}
+			values[i] = unescapeCopy(f)
+		}
+		if err := d.insertRow(t, cols, values); err != nil {
+			return err
+		}
+	}
+}
+
+func (d *SQLDump) insertRow(t *sqlTable, cols []string, values []interface{}) error {
+	row := make(opencdc.StructuredData, len(t.columns))
+	for _, c := range t.columns {
+		row[c.name] = c.defaultV
+	}
+	for i, name := range cols {
+		v, err := t.convert(name, values[i])
+		if err != nil {
+			return err
+		}
+		row[name] = v
+	}
+	t.rows = append(t.rows, row)
+
+	d.record(t, opencdc.OperationCreate, nil, row)
+	return nil
+}
+
+func (d *SQLDump) update(p *sqlParser) error {
+	p.keyword("only")
+	name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	t, ok := d.tables[name]
+	if !ok {
+		return fmt.Errorf("table %s is not defined", name)
+	}
+	p.alias()
+
+	if !p.keyword("set") {
+		return fmt.Errorf("expected SET, found %s", p.peek())
+	}
+	set := map[string]interface{}{}
+	for {
+		col, err := p.ident()
+		if err != nil {
+			return err
+		}
+		if !p.punct("=") {
+			return fmt.Errorf("expected = after %s, found %s", col, p.peek())
+		}
+		v, err := p.literal()
+		if err != nil {
+			return err
+		}
+		if set[col], err = t.convert(col, v); err != nil {
+			return err
+		}
+		if !p.punct(",") {
+			break
+		}
+	}
+
+	match, err := t.where(p)
+	if err != nil {
+		return err
+	}
+	for i, row := range t.rows {
+		if !match(row) {
+			continue
+		}
+		after := row.Clone().(opencdc.StructuredData)
+		for k, v := range set {
+			after[k] = v
+		}
+		t.rows[i] = after
+		d.record(t, opencdc.OperationUpdate, row, after)
+	}
+	return nil
+}
+
+func (d *SQLDump) delete(p *sqlParser) error {
+	if !p.keyword("from") {
+		return fmt.Errorf("expected DELETE FROM")
+	}
+	p.keyword("only")
+	name, err := p.tableName()
+	if err != nil {
+		return err
+	}
+	t, ok := d.tables[name]
+	if !ok {
+		return fmt.Errorf("table %s is not defined", name)
+	}
+	p.alias()
+
+	match, err := t.where(p)
+	if err != nil {
+		return err
+	}
+	rows := t.rows[:0]
+	for _, row := range t.rows {
+		if !match(row) {
+			rows = append(rows, row)
+			continue
+		}
+		d.record(t, opencdc.OperationDelete, row, nil)
+	}
+	t.rows = rows
+	return nil
+}
+
+// record appends a change record. Positions are increasing log sequence
+// numbers, like the ones of logical replication.
+func (d *SQLDump) record(t *sqlTable, op opencdc.Operation, before, after opencdc.StructuredData) {
+	d.lsn++
+	r := opencdc.Record{
+		Position:  opencdc.Position(fmt.Sprintf("0/%X", d.lsn)),
+		Operation: op,
+		Metadata:  opencdc.Metadata{MetadataPostgresTable: t.name},
+	}
+	if before != nil {
+		r.Payload.Before = before.Clone()
+		r.Key = t.key(before)
+	}
+	if after != nil {
+		r.Payload.After = after.Clone()
+		r.Key = t.key(after)
+	}
+	d.records = append(d.records, r)
+}
+
+// table returns a table, defining it from the given columns when the dump
+// holds no CREATE TABLE statement for it.
+func (d *SQLDump) table(name string, cols []string) (*sqlTable, error) {
+	if t, ok := d.tables[name]; ok {
+		return t, nil
+	}
+	if cols == nil {
+		return nil, fmt.Errorf("table %s is not defined, list the columns of the statement", name)
+	}
+
+	t := &sqlTable{name: tableBaseName(name)}
+	for _, c := range cols {
+		t.columns = append(t.columns, sqlColumn{name: c})
+	}
+	d.tables[name] = t
+	d.order = append(d.order, name)
+	return t, nil
+}
+
+func (t *sqlTable) columnNames() []string {
+	names := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+// key returns the primary key of a row, or nil if the table has none.
+func (t *sqlTable) key(row opencdc.StructuredData) opencdc.Data {
+	if len(t.primary) == 0 {
+		return nil
+	}
+	key := make(opencdc.StructuredData, len(t.primary))
+	for _, c := range t.primary {
+		key[c] = row[c]
+	}
+	return key
+}
+
+// convert converts a literal to the type of a column. Strings are parsed as
+// numbers and booleans for columns of those types, other values are kept.
+func (t *sqlTable) convert(column string, v interface{}) (interface{}, error) {
+	var col *sqlColumn
+	for i := range t.columns {
+		if t.columns[i].name == column {
+			col = &t.columns[i]
+			break
+		}
+	}
+	if col == nil {
+		return nil, fmt.Errorf("column %q of table %s is not defined", column, t.name)
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	typ := strings.ToLower(col.typ)
+	switch {
+	case isIntType(typ):
+		switch v := v.(type) {
+		case string:
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value %q for column %q", typ, v, column)
+			}
+			return n, nil
+		case float64:
+			return int64(v), nil
+		}
+	case isFloatType(typ):
+		switch v := v.(type) {
+		case string:
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s value %q for column %q", typ, v, column)
+			}
+			return f, nil
+		case int64:
+			return float64(v), nil
+		}
+	case typ == "boolean" || typ == "bool":
+		if s, ok := v.(string); ok {
+			switch strings.ToLower(s) {
+			case "t", "true", "y", "yes", "on", "1":
+				return true, nil
+			case "f", "false", "n", "no", "off", "0":
+				return false, nil
+			default:
+				return nil, fmt.Errorf("invalid boolean value %q for column %q", s, column)
+			}
+		}
+	case typ != "":
+		// Text, dates, JSON and the like are kept as their text form.
+		switch v := v.(type) {
+		case int64:
+			return strconv.FormatInt(v, 10), nil
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	}
+	return v, nil
+}
+
+func isIntType(typ string) bool {
+	switch typ {
+	case "smallint", "integer", "int", "bigint", "int2", "int4", "int8",
+		"smallserial", "serial", "bigserial", "serial2", "serial4", "serial8":
+		return true
+	}
+	return false
+}
+
+func isFloatType(typ string) bool {
+	return typ == "real" || typ == "float4" || typ == "float8" || typ == "double precision" ||
+		strings.HasPrefix(typ, "numeric") || strings.HasPrefix(typ, "decimal") || strings.HasPrefix(typ, "float")
+}
+
+// where parses an optional WHERE clause into a row filter.
+func (t *sqlTable) where(p *sqlParser) (func(opencdc.StructuredData) bool, error) {
+	if !p.keyword("where") {
+		if !p.done() {
+			return nil, fmt.Errorf("unexpected %s", p.peek())
+		}
+		return func(opencdc.StructuredData) bool { return true }, nil
+	}
+
+	type condition struct {
+		column string
+		values []interface{}
+	}
+	var conds []condition
+	for {
+		col, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		var values []interface{}
+		switch {
+		case p.punct("="):
+			v, err := p.literal()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		case p.keyword("in"):
+			if !p.punct("(") {
+				return nil, fmt.Errorf("expected ( after IN, found %s", p.peek())
+			}
+			for {
+				v, err := p.literal()
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, v)
+				if p.punct(")") {
+					break
+				}
+				if !p.punct(",") {
+					return nil, fmt.Errorf("expected , or ) in IN list, found %s", p.peek())
+				}
+			}
+		default:
+			return nil, fmt.Errorf("only column = value and column IN (values) conditions are supported, found %s", p.peek())
+		}
+		for i, v := range values {
+			if values[i], err = t.convert(col, v); err != nil {
+				return nil, err
+			}
+		}
+		conds = append(conds, condition{column: col, values: values})
+
+		if !p.keyword("and") {
+			break
+		}
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unsupported WHERE clause near %s", p.peek())
+	}
+
+	return func(row opencdc.StructuredData) bool {
+		for _, c := range conds {
+			found := false
+			for _, v := range c.values {
+				// SQL never matches NULL with =.
+				if v != nil && row[c.column] == v {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// tableBaseName drops the schema of a table name.
+func tableBaseName(name string) string {
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func unescapeCopy(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		case 'r':
+			b.WriteByte('\r')
+		case 'b':
+			b.WriteByte('\b')
+		case 'f':
+			b.WriteByte('\f')
+		case 'v':
+			b.WriteByte('\v')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+type sqlTokenKind int
+
+const (
+	sqlIdent sqlTokenKind = iota
+	sqlQuotedIdent
+	sqlString
+	sqlNumber
+	sqlPunct
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+	line int
+}
+
+func (t sqlToken) String() string {
+	if t.kind == sqlString {
+		return "'" + t.text + "'"
+	}
+	return strconv.Quote(t.text)
+}
+
+// sqlLexer splits SQL into statements of tokens, skipping comments.
+type sqlLexer struct {
+	src  string
+	pos  int
+	line int
+}
+
+// statement returns the tokens of the next statement, without its
+// terminating semicolon.
+func (l *sqlLexer) statement() ([]sqlToken, error) {
+	var tokens []sqlToken
+	for {
+		tok, err := l.token()
+		if errors.Is(err, io.EOF) {
+			if len(tokens) == 0 {
+				return nil, io.EOF
+			}
+			return tokens, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if tok.kind == sqlPunct && tok.text == ";" {
+			return tokens, nil
+		}
+		tokens = append(tokens, tok)
+	}
+}
+
+// rawLine returns the next line of input, used by COPY data.
+func (l *sqlLexer) rawLine() (string, bool) {
+	// The rest of the line of the COPY statement is empty.
+	if l.pos < len(l.src) && l.src[l.pos] == '\n' {
+		l.pos++
+		l.line++
+	}
+	if l.pos >= len(l.src) {
+		return "", false
+	}
+
+	end := strings.IndexByte(l.src[l.pos:], '\n')
+	if end < 0 {
+		end = len(l.src) - l.pos
+	}
+	line := strings.TrimSuffix(l.src[l.pos:l.pos+end], "\r")
+	l.pos += end
+	if l.pos < len(l.src) {
+		l.pos++
+	}
+	l.line++
+	return line, true
+}
+
+func (l *sqlLexer) token() (sqlToken, error) {
+	l.skipSpace()
+	if l.pos >= len(l.src) {
+		return sqlToken{}, io.EOF
+	}
+
+	start, line := l.pos, l.line
+	c := l.src[l.pos]
+	switch {
+	case c == '\'':
+		s, err := l.quoted('\'', false)
+		return sqlToken{kind: sqlString, text: s, line: line}, err
+	case (c == 'E' || c == 'e') && l.pos+1 < len(l.src) && l.src[l.pos+1] == '\'':
+		l.pos++
+		s, err := l.quoted('\'', true)
+		return sqlToken{kind: sqlString, text: s, line: line}, err
+	case c == '"':
+		s, err := l.quoted('"', false)
+		return sqlToken{kind: sqlQuotedIdent, text: s, line: line}, err
+	case c == '$':
+		// Dollar-quoted string, $tag$...$tag$.
+		end := strings.IndexByte(l.src[l.pos+1:], '$')
+		if end < 0 {
+			break
+		}
+		tag := l.src[l.pos : l.pos+end+2]
+		body := strings.Index(l.src[l.pos+len(tag):], tag)
+		if body < 0 {
+			return sqlToken{}, fmt.Errorf("unterminated dollar-quoted string")
+		}
+		s := l.src[l.pos+len(tag) : l.pos+len(tag)+body]
+		l.line += strings.Count(s, "\n")
+		l.pos += len(tag) + body + len(tag)
+		return sqlToken{kind: sqlString, text: s, line: line}, nil
+	case c >= '0' && c <= '9' || c == '.' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1]):
+		for l.pos < len(l.src) && (isDigit(l.src[l.pos]) || l.src[l.pos] == '.' ||
+			l.src[l.pos] == 'e' || l.src[l.pos] == 'E' ||
+			(l.src[l.pos] == '-' || l.src[l.pos] == '+') && (l.src[l.pos-1] == 'e' || l.src[l.pos-1] == 'E')) {
+			l.pos++
+		}
+		return sqlToken{kind: sqlNumber, text: l.src[start:l.pos], line: line}, nil
+	case c == '_' || unicode.IsLetter(rune(c)) || c >= 0x80:
+		for l.pos < len(l.src) {
+			c := l.src[l.pos]
+			if c != '_' && c != '$' && !isDigit(c) && !unicode.IsLetter(rune(c)) && c < 0x80 {
+				break
+			}
+			l.pos++
+		}
+		return sqlToken{kind: sqlIdent, text: strings.ToLower(l.src[start:l.pos]), line: line}, nil
+	case c == ':' && l.pos+1 < len(l.src) && l.src[l.pos+1] == ':':
+		l.pos += 2
+		return sqlToken{kind: sqlPunct, text: "::", line: line}, nil
+	}
+
+	l.pos++
+	return sqlToken{kind: sqlPunct, text: string(c), line: line}, nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (l *sqlLexer) skipSpace() {
+	for l.pos < len(l.src) {
+		switch {
+		case l.src[l.pos] == '\n':
+			l.line++
+			l.pos++
+		case l.src[l.pos] == ' ' || l.src[l.pos] == '\t' || l.src[l.pos] == '\r':
+			l.pos++
+		case strings.HasPrefix(l.src[l.pos:], "--"):
+			end := strings.IndexByte(l.src[l.pos:], '\n')
+			if end < 0 {
+				l.pos = len(l.src)
+				return
+			}
+			l.pos += end
+		case strings.HasPrefix(l.src[l.pos:], "/*"):
+			end := strings.Index(l.src[l.pos:], "*/")
+			if end < 0 {
+				end = len(l.src) - l.pos - 2
+			}
+			l.line += strings.Count(l.src[l.pos:l.pos+end+2], "\n")
+			l.pos += end + 2
+		default:
+			return
+		}
+	}
+}
+
+// quoted reads a quoted string or identifier, a doubled quote escapes it.
+// Backslash escapes are only honored in E'...' strings.
+func (l *sqlLexer) quoted(q byte, escapes bool) (string, error) {
+	line := l.line
+	l.pos++ // opening quote
+
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == q && l.pos+1 < len(l.src) && l.src[l.pos+1] == q:
+			b.WriteByte(q)
+			l.pos += 2
+		case c == q:
+			l.pos++
+			return b.String(), nil
+		case escapes && c == '\\' && l.pos+1 < len(l.src):
+			b.WriteString(unescapeCopy(l.src[l.pos : l.pos+2]))
+			l.pos += 2
+		default:
+			if c == '\n' {
+				l.line++
+			}
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated quoted string starting on line %d", line)
+}
+
+// sqlParser walks the tokens of a statement.
+type sqlParser struct {
+	tokens []sqlToken
+	pos    int
+}
+
+func (p *sqlParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *sqlParser) peek() sqlToken {
+	if p.done() {
+		return sqlToken{kind: sqlPunct, text: "end of statement"}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *sqlParser) next() sqlToken {
+	tok := p.peek()
+	if !p.done() {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *sqlParser) peekKeyword(kw string) bool {
+	tok := p.peek()
+	return !p.done() && tok.kind == sqlIdent && tok.text == kw
+}
+
+func (p *sqlParser) keyword(kw string) bool {
+	if p.peekKeyword(kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *sqlParser) peekPunct(s string) bool {
+	tok := p.peek()
+	return !p.done() && tok.kind == sqlPunct && tok.text == s
+}
+
+func (p *sqlParser) punct(s string) bool {
+	if p.peekPunct(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *sqlParser) ident() (string, error) {
+	tok := p.peek()
+	if p.done() || (tok.kind != sqlIdent && tok.kind != sqlQuotedIdent) {
+		return "", fmt.Errorf("expected identifier, found %s", tok)
+	}
+	p.pos++
+	return tok.text, nil
+}
+
+// tableName parses a table name, qualifying it with the public schema.
+func (p *sqlParser) tableName() (string, error) {
+	name, err := p.ident()
+	if err != nil {
+		return "", err
+	}
+	if !p.punct(".") {
+		return "public." + name, nil
+	}
+	table, err := p.ident()
+	if err != nil {
+		return "", err
+	}
+	return name + "." + table, nil
+}
+
+// alias skips an optional table alias.
+func (p *sqlParser) alias() {
+	if p.keyword("as") {
+		p.next()
+		return
+	}
+	if tok := p.peek(); !p.done() && (tok.kind == sqlQuotedIdent ||
+		tok.kind == sqlIdent && tok.text != "set" && tok.text != "where") {
+		p.pos++
+	}
+}
+
+func (p *sqlParser) identList() ([]string, error) {
+	if !p.punct("(") {
+		return nil, fmt.Errorf("expected (, found %s", p.peek())
+	}
+	var names []string
+	for {
+		name, err := p.ident()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+		if p.punct(")") {
+			return names, nil
+		}
+		if !p.punct(",") {
+			return nil, fmt.Errorf("expected , or ), found %s", p.peek())
+		}
+	}
+}
+
+// literal parses a constant, optionally signed and cast with ::type. Numbers
+// become int64 or float64, strings keep their text.
+func (p *sqlParser) literal() (interface{}, error) {
+	neg := p.punct("-")
+	if !neg {
+		p.punct("+")
+	}
+
+	tok := p.peek()
+	var v interface{}
+	switch {
+	case tok.kind == sqlNumber:
+		text := tok.text
+		if neg {
+			text = "-" + text
+		}
+		if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+			v = n
+		} else if f, err := strconv.ParseFloat(text, 64); err == nil {
+			v = f
+		} else {
+			return nil, fmt.Errorf("invalid number %s", tok)
+		}
+	case neg:
+		return nil, fmt.Errorf("expected number after -, found %s", tok)
+	case tok.kind == sqlString:
+		v = tok.text
+	case tok.kind == sqlIdent && (tok.text == "true" || tok.text == "false"):
+		v = tok.text == "true"
+	case tok.kind == sqlIdent && tok.text == "null":
+		v = nil
+	default:
+		return nil, fmt.Errorf("only literal values are supported, found %s", tok)
+	}
+	p.pos++
+
+	// Casts don't change the value, columns types are applied later.
+	for p.punct("::") {
+		if _, err := p.ident(); err != nil {
+			return nil, err
+		}
+		for !p.done() && p.peek().kind == sqlIdent && !isClauseKeyword(p.peek().text) {
+			p.pos++
+		}
+		if p.peekPunct("(") {
+			p.skipGroup()
+		}
+		for p.punct("[") {
+			p.punct("]")
+		}
+	}
+	return v, nil
+}
+
+func isClauseKeyword(s string) bool {
+	switch s {
+	case "and", "or", "where", "not", "null", "on", "returning", "collate", "check", "references",
+		"primary", "unique", "constraint", "default", "generated", "set":
+		return true
+	}
+	return false
+}
+
+// skipGroup skips a parenthesized group.
+func (p *sqlParser) skipGroup() {
+	depth := 0
+	for !p.done() {
+		tok := p.next()
+		if tok.kind != sqlPunct {
+			continue
+		}
+		switch tok.text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth <= 0 {
+				return
+			}
+		}
+	}
+}
+
+// skipExpr skips tokens up to the next , or ) outside parentheses.
+func (p *sqlParser) skipExpr() {
+	p.next()
+	for !p.done() && !p.peekPunct(",") && !p.peekPunct(")") {
+		if p.peekPunct("(") {
+			p.skipGroup()
+			continue
+		}
+		if p.peek().kind == sqlIdent && isClauseKeyword(p.peek().text) {
+			return
+		}
+		p.next()
+	}
+}
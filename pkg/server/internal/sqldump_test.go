@@ -0,0 +1,274 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLRecords_DemoCDC(t *testing.T) {
+	records, err := SQLRecords(false,
+		"../../../fixtures/sql/schema.sql",
+		"../../../fixtures/sql/data-cdc.sql",
+		"../../../fixtures/sql/data-update-cdc.sql",
+	)
+	require.NoError(t, err)
+
+	// demo-cdc.json was captured by Conduit from the same statements.
+	b, err := os.ReadFile("../../../fixtures/demo-cdc.json")
+	require.NoError(t, err)
+	var want []opencdc.Record
+	require.NoError(t, json.Unmarshal(b, &want))
+
+	require.Len(t, records, len(want))
+	positions := map[string]bool{}
+	for i, r := range records {
+		require.Equal(t, want[i].Operation, r.Operation, "record %d", i)
+		require.Equal(t, "orders", r.Metadata[MetadataPostgresTable])
+		require.JSONEq(t, string(want[i].Key.Bytes()), string(r.Key.Bytes()), "record %d", i)
+		if r.Operation != opencdc.OperationDelete {
+			require.JSONEq(t, string(want[i].Payload.After.Bytes()), string(r.Payload.After.Bytes()), "record %d", i)
+		}
+		if r.Operation != opencdc.OperationCreate {
+			require.NotNil(t, r.Payload.Before, "record %d", i)
+		}
+
+		require.False(t, positions[string(r.Position)])
+		positions[string(r.Position)] = true
+	}
+}
+
+func TestSQLRecords_Snapshot(t *testing.T) {
+	records, err := SQLRecords(true,
+		"../../../fixtures/sql/schema.sql",
+		"../../../fixtures/sql/data-cdc.sql",
+		"../../../fixtures/sql/data-update-cdc.sql",
+	)
+	require.NoError(t, err)
+
+	// Rows 3, 5, 8 and 10 were deleted.
+	require.Len(t, records, 6)
+	for _, r := range records {
+		require.Equal(t, opencdc.OperationSnapshot, r.Operation)
+		require.Nil(t, r.Payload.Before)
+	}
+	require.Equal(t, opencdc.StructuredData{"id": int64(1)}, records[0].Key)
+	require.Equal(t, "Electronics Updated", records[0].Payload.After.(opencdc.StructuredData)["category"])
+}
+
+func TestSQLDump_Parse(t *testing.T) {
+	tests := []struct {
+		desc string
+		sql  string
+		want []opencdc.Record
+	}{
+		{
+			desc: "COPY from a pg_dump",
+			sql: `CREATE TABLE "Users" (
+    id integer NOT NULL,
+    name text COLLATE "C",
+    score numeric(5,2),
+    active boolean DEFAULT true,
+    CONSTRAINT users_pkey PRIMARY KEY (id)
+);
+COPY public."Users" (id, name, score, active) FROM stdin;
+1	Ada\tL.	9.5	t
+2	\N	7	f
+\.
+`,
+			want: []opencdc.Record{
+				{
+					Position:  opencdc.Position("0/1"),
+					Operation: opencdc.OperationCreate,
+					Metadata:  opencdc.Metadata{MetadataPostgresTable: "Users"},
+					Key:       opencdc.StructuredData{"id": int64(1)},
+					Payload: opencdc.Change{After: opencdc.StructuredData{
+						"id": int64(1), "name": "Ada\tL.", "score": 9.5, "active": true,
+					}},
+				},
+				{
+					Position:  opencdc.Position("0/2"),
+					Operation: opencdc.OperationCreate,
+					Metadata:  opencdc.Metadata{MetadataPostgresTable: "Users"},
+					Key:       opencdc.StructuredData{"id": int64(2)},
+					Payload: opencdc.Change{After: opencdc.StructuredData{
+						"id": int64(2), "name": nil, "score": float64(7), "active": false,
+					}},
+				},
+			},
+		},
+		{
+			desc: "defaults, casts and IN conditions",
+			sql: `CREATE TABLE items (
+    sku character varying(16) PRIMARY KEY,
+    qty bigint DEFAULT 0,
+    note text DEFAULT 'n/a'::text
+);
+INSERT INTO items (sku) VALUES ('a'), ('b'), ('c');
+UPDATE items AS i SET qty = '-3'::bigint, note = E'it''s\n' WHERE sku IN ('a', 'c');
+DELETE FROM ONLY items WHERE sku = 'c' AND qty = -3;
+DELETE FROM items WHERE sku = 'missing';`,
+			want: []opencdc.Record{
+				{
+					Position:  opencdc.Position("0/1"),
+					Operation: opencdc.OperationCreate,
+					Metadata:  opencdc.Metadata{MetadataPostgresTable: "items"},
+					Key:       opencdc.StructuredData{"sku": "a"},
+					Payload:   opencdc.Change{After: opencdc.StructuredData{"sku": "a", "qty": int64(0), "note": "n/a"}},
+				},
+				{
+					Position:  opencdc.Position("0/2"),
+					Operation: opencdc.OperationCreate,
+					Metadata:  opencdc.Metadata{MetadataPostgresTable: "items"},
+					Key:       opencdc.StructuredData{"sku": "b"},
+					Payload:   opencdc.Change{After: opencdc.StructuredData{"sku": "b", "qty": int64(0), "note": "n/a"}},
+				},
+				{
+					Position:  opencdc.Position("0/3"),
+					Operation: opencdc.OperationCreate,
+					Metadata:  opencdc.Metadata{MetadataPostgresTable: "items"},
+					Key:       opencdc.StructuredData{"sku": "c"},
+					Payload:   opencdc.Change{After: opencdc.StructuredData{"sku": "c", "qty": int64(0), "note": "n/a"}},
+				},
+				{
+					Position:  opencdc.Position("0/4"),
+					Operation: opencdc.OperationUpdate,
+					Metadata:  opencdc.Metadata{MetadataPostgresTable: "items"},
+					Key:       opencdc.StructuredData{"sku": "a"},
+					Payload: opencdc.Change{
+						Before: opencdc.StructuredData{"sku": "a", "qty": int64(0), "note": "n/a"},
+						After:  opencdc.StructuredData{"sku": "a", "qty": int64(-3), "note": "it's\n"},
+					},
+				},
+				{
+					Position:  opencdc.Position("0/5"),
+					Operation: opencdc.OperationUpdate,
+					Metadata:  opencdc.Metadata{MetadataPostgresTable: "items"},
+					Key:       opencdc.StructuredData{"sku": "c"},
+					Payload: opencdc.Change{
+						Before: opencdc.StructuredData{"sku": "c", "qty": int64(0), "note": "n/a"},
+						After:  opencdc.StructuredData{"sku": "c", "qty": int64(-3), "note": "it's\n"},
+					},
+				},
+				{
+					Position:  opencdc.Position("0/6"),
+					Operation: opencdc.OperationDelete,
+					Metadata:  opencdc.Metadata{MetadataPostgresTable: "items"},
+					Key:       opencdc.StructuredData{"sku": "c"},
+					Payload: opencdc.Change{
+						Before: opencdc.StructuredData{"sku": "c", "qty": int64(-3), "note": "it's\n"},
+					},
+				},
+			},
+		},
+		{
+			desc: "tables without a schema take the inserted columns",
+			sql: `/* no schema */ INSERT INTO events (id, kind) VALUES (1, 'click');
+DROP TABLE events;
+INSERT INTO events (id, kind) VALUES (2.5, NULL);`,
+			want: []opencdc.Record{
+				{
+					Position:  opencdc.Position("0/1"),
+					Operation: opencdc.OperationCreate,
+					Metadata:  opencdc.Metadata{MetadataPostgresTable: "events"},
+					Payload:   opencdc.Change{After: opencdc.StructuredData{"id": int64(1), "kind": "click"}},
+				},
+				{
+					Position:  opencdc.Position("0/2"),
+					Operation: opencdc.OperationCreate,
+					Metadata:  opencdc.Metadata{MetadataPostgresTable: "events"},
+					Payload:   opencdc.Change{After: opencdc.StructuredData{"id": 2.5, "kind": nil}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			d := NewSQLDump()
+			require.NoError(t, d.Parse(tc.sql))
+			require.Equal(t, tc.want, d.Records())
+		})
+	}
+}
+
+func TestSQLDump_ParseErrors(t *testing.T) {
+	tests := []struct {
+		desc    string
+		sql     string
+		wantErr string
+	}{
+		{
+			desc:    "unknown table",
+			sql:     "-- comment\n\nUPDATE orders SET id = 1;",
+			wantErr: "line 3: table public.orders is not defined",
+		},
+		{
+			desc:    "insert without columns into an unknown table",
+			sql:     "INSERT INTO orders VALUES (1);",
+			wantErr: "line 1: table public.orders is not defined, list the columns of the statement",
+		},
+		{
+			desc:    "column count mismatch",
+			sql:     "CREATE TABLE t (id int);\nINSERT INTO t\nVALUES (1, 2);",
+			wantErr: "line 2: INSERT INTO public.t has 1 columns but 2 values",
+		},
+		{
+			desc:    "unknown column",
+			sql:     "CREATE TABLE t (id int);\nINSERT INTO t (name) VALUES ('a');",
+			wantErr: `line 2: column "name" of table t is not defined`,
+		},
+		{
+			desc:    "invalid value",
+			sql:     "CREATE TABLE t (id int);\nINSERT INTO t (id) VALUES ('one');",
+			wantErr: `line 2: invalid int value "one" for column "id"`,
+		},
+		{
+			desc:    "expressions",
+			sql:     "CREATE TABLE t (id int);\nINSERT INTO t (id) VALUES (now());",
+			wantErr: `line 2: only literal values are supported, found "now"`,
+		},
+		{
+			desc:    "unsupported conditions",
+			sql:     "CREATE TABLE t (id int);\nDELETE FROM t WHERE id > 1;",
+			wantErr: `line 2: only column = value and column IN (values) conditions are supported, found ">"`,
+		},
+		{
+			desc:    "unterminated string",
+			sql:     "CREATE TABLE t (id int);\nINSERT INTO t (id) VALUES ('1);",
+			wantErr: "line 2: unterminated quoted string starting on line 2",
+		},
+		{
+			desc:    "unterminated COPY",
+			sql:     "CREATE TABLE t (id int);\nCOPY t (id) FROM stdin;\n1\n",
+			wantErr: `line 2: COPY data for public.t is not terminated by \.`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			require.EqualError(t, NewSQLDump().Parse(tc.sql), tc.wantErr)
+		})
+	}
+}
+
+func TestReadFixture_SQL(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "orders.sql")
+	require.NoError(t, os.WriteFile(file, []byte(`CREATE TABLE orders (id bigint PRIMARY KEY);
+INSERT INTO orders VALUES (1), (2);
+DELETE FROM orders WHERE id = 1;`), 0o644))
+
+	records, err := ReadFixture(context.Background(), file, "")
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	var r opencdc.Record
+	require.NoError(t, r.FromProto(records[2]))
+	require.Equal(t, opencdc.OperationDelete, r.Operation)
+	require.Equal(t, "orders", r.Metadata[MetadataPostgresTable])
+}
@@ -6,6 +6,7 @@ package mock
 
 import (
 	context "context"
+	net "net"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,6 +35,20 @@ func (m *MockServer) EXPECT() *MockServerMockRecorder {
 	return m.recorder
 }
 
+// Addr mocks base method.
+func (m *MockServer) Addr() net.Addr {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Addr")
+	ret0, _ := ret[0].(net.Addr)
+	return ret0
+}
+
+// Addr indicates an expected call of Addr.
+func (mr *MockServerMockRecorder) Addr() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Addr", reflect.TypeOf((*MockServer)(nil).Addr))
+}
+
 // GracefulStop mocks base method.
 func (m *MockServer) GracefulStop() {
 	m.ctrl.T.Helper()
@@ -46,10 +61,40 @@ func (mr *MockServerMockRecorder) GracefulStop() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GracefulStop", reflect.TypeOf((*MockServer)(nil).GracefulStop))
 }
 
+// ListenErr mocks base method.
+func (m *MockServer) ListenErr() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListenErr")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListenErr indicates an expected call of ListenErr.
+func (mr *MockServerMockRecorder) ListenErr() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListenErr", reflect.TypeOf((*MockServer)(nil).ListenErr))
+}
+
+// Ready mocks base method.
+func (m *MockServer) Ready() <-chan struct{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ready")
+	ret0, _ := ret[0].(<-chan struct{})
+	return ret0
+}
+
+// Ready indicates an expected call of Ready.
+func (mr *MockServerMockRecorder) Ready() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ready", reflect.TypeOf((*MockServer)(nil).Ready))
+}
+
 // Run mocks base method.
-func (m *MockServer) Run(arg0 context.Context) {
+func (m *MockServer) Run(arg0 context.Context) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Run", arg0)
+	ret := m.ctrl.Call(m, "Run", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // Run indicates an expected call of Run.
@@ -59,9 +104,11 @@ func (mr *MockServerMockRecorder) Run(arg0 interface{}) *gomock.Call {
 }
 
 // RunAddr mocks base method.
-func (m *MockServer) RunAddr(arg0 context.Context, arg1 string) {
+func (m *MockServer) RunAddr(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "RunAddr", arg0, arg1)
+	ret := m.ctrl.Call(m, "RunAddr", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // RunAddr indicates an expected call of RunAddr.
@@ -2,24 +2,75 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path"
+	"sync"
 
+	"github.com/conduitio/conduit-commons/opencdc"
 	"github.com/meroxa/turbine-core/v2/pkg/app"
+	"github.com/meroxa/turbine-core/v2/pkg/ir"
 	"github.com/meroxa/turbine-core/v2/pkg/server/internal"
+	"github.com/meroxa/turbine-core/v2/proto/process/v2"
 	"github.com/meroxa/turbine-core/v2/proto/turbine/v2"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 var _ turbinev2.ServiceServer = (*RunService)(nil)
 
+const (
+	// defaultBatchSize is the number of records streamed per response when
+	// the client does not ask for a batch size.
+	defaultBatchSize = 1000
+	// maxBatchBytes bounds the size of a streamed batch, well below the
+	// default gRPC message size limit.
+	maxBatchBytes = 1 << 20
+)
+
 type RunService struct {
 	turbinev2.UnimplementedServiceServer
 
 	config  app.Config
 	appPath string
+	dotEnv  map[string]string
+
+	mu         sync.Mutex
+	processors map[string]*functionProcess
+	sinks      map[string]internal.Sink
+	// secrets holds the values of the registered secrets.
+	secrets map[string]string
+	// pluginConfigs holds the plugin config of every connector with its
+	// secret references resolved.
+	pluginConfigs map[string]map[string]string
+}
+
+// functionProcess is a connection to a function process serving
+// process.v2.ProcessorService.
+type functionProcess struct {
+	client processv2.ProcessorServiceClient
+	conn   *grpc.ClientConn
+}
+
+// dialProcessor connects to a function process serving process.v2.ProcessorService.
+var dialProcessor = func(ctx context.Context, addr string) (*functionProcess, error) {
+	conn, err := grpc.DialContext(
+		ctx,
+		addr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &functionProcess{
+		client: processv2.NewProcessorServiceClient(conn),
+		conn:   conn,
+	}, nil
 }
 
 func NewRunService() *RunService {
@@ -37,17 +88,132 @@ func (s *RunService) Init(_ context.Context, req *turbinev2.InitRequest) (*empty
 	if err != nil {
 		return nil, err
 	}
+	dotEnv, err := app.ReadDotEnv(req.ConfigFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Nothing of the previous app is kept: function addresses, sinks and
+	// connectors may differ in the new config.
+	_ = s.closeProcessors()
+	s.sinks = nil
+	s.secrets = nil
+	s.pluginConfigs = nil
+
 	s.config = config
 	s.appPath = req.ConfigFilePath
+	s.dotEnv = dotEnv
 
 	return empty(), nil
 }
 
+// App returns the config and path of the app set by Init.
+func (s *RunService) App() (app.Config, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.config, s.appPath
+}
+
+// RegisterSecret looks up the value of a secret in the environment, then in
+// the .env file of the app.
+func (s *RunService) RegisterSecret(_ context.Context, req *turbinev2.RegisterSecretRequest) (*emptypb.Empty, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.lookupEnv(req.Name)
+	if !ok {
+		return nil, status.Error(
+			codes.FailedPrecondition,
+			fmt.Sprintf(
+				"secret %s is not set, export it or add it to %s",
+				req.Name,
+				path.Join(s.appPath, app.DotEnvFile),
+			),
+		)
+	}
+
+	if s.secrets == nil {
+		s.secrets = make(map[string]string)
+	}
+	s.secrets[req.Name] = value
+
+	return empty(), nil
+}
+
+// resolvePluginConfig resolves the ${NAME} placeholders of a connector's
+// plugin config from the environment or the .env file of the app, then
+// replaces its secret references with the values of the registered secrets.
+func (s *RunService) resolvePluginConfig(name string, plugin *turbinev2.Plugin) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config := make(map[string]string, len(plugin.GetConfig()))
+	for k, v := range plugin.GetConfig() {
+		resolved, err := ir.ResolvePlaceholders(v, s.lookupEnv)
+		if err != nil {
+			return status.Error(
+				codes.InvalidArgument,
+				fmt.Sprintf("connector %s config %q: %s, export it or add it to %s", name, k, err, path.Join(s.appPath, app.DotEnvFile)),
+			)
+		}
+
+		resolved, err = ir.ResolveSecrets(resolved, func(secret string) (string, bool) {
+			value, ok := s.secrets[secret]
+			return value, ok
+		})
+		if err != nil {
+			return status.Error(
+				codes.InvalidArgument,
+				fmt.Sprintf("connector %s config %q: %s, register the secret before using it", name, k, err),
+			)
+		}
+		config[k] = resolved
+	}
+
+	if s.pluginConfigs == nil {
+		s.pluginConfigs = make(map[string]map[string]string)
+	}
+	s.pluginConfigs[name] = config
+	return nil
+}
+
+// lookupEnv looks up a variable in the environment, then in the .env file
+// of the app. The caller holds s.mu.
+func (s *RunService) lookupEnv(name string) (string, bool) {
+	if v, ok := os.LookupEnv(name); ok {
+		return v, true
+	}
+	v, ok := s.dotEnv[name]
+	return v, ok
+}
+
+// PluginConfig returns the plugin config of a connector with its secrets
+// resolved, and false when no connector with that name was added.
+func (s *RunService) PluginConfig(connector string) (map[string]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	config, ok := s.pluginConfigs[connector]
+	return config, ok
+}
+
 func (s *RunService) AddSource(_ context.Context, req *turbinev2.AddSourceRequest) (*turbinev2.AddSourceResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
+	if err := s.resolvePluginConfig(req.Name, req.Plugin); err != nil {
+		return nil, err
+	}
+
 	return &turbinev2.AddSourceResponse{
 		StreamName: req.Name,
 	}, nil
@@ -58,18 +224,13 @@ func (s *RunService) ReadRecords(ctx context.Context, req *turbinev2.ReadRecords
 		return nil, err
 	}
 
-	fixtureFile, ok := s.config.Fixtures[req.SourceStream]
-	if !ok {
-		return nil, status.Error(
-			codes.InvalidArgument,
-			fmt.Sprintf(
-				"no fixture file found for source %s. Ensure that the source is declared in your app.json.",
-				req.SourceStream,
-			),
-		)
+	src, err := s.openSource(req.SourceStream)
+	if err != nil {
+		return nil, err
 	}
+	defer src.Close()
 
-	rr, err := internal.ReadFixture(ctx, path.Join(s.appPath, fixtureFile))
+	rr, err := internal.ReadAll(ctx, src)
 	if err != nil {
 		return nil, err
 	}
@@ -82,35 +243,218 @@ func (s *RunService) ReadRecords(ctx context.Context, req *turbinev2.ReadRecords
 	}, nil
 }
 
+func (s *RunService) ReadRecordsStream(req *turbinev2.ReadRecordsStreamRequest, stream turbinev2.Service_ReadRecordsStreamServer) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	fr, err := s.openSource(req.SourceStream)
+	if err != nil {
+		return err
+	}
+	defer fr.Close()
+
+	batchSize := int(req.BatchSize)
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+
+	for {
+		rr, err := fr.Next(stream.Context(), batchSize, maxBatchBytes)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(&turbinev2.ReadRecordsResponse{
+			StreamRecords: &turbinev2.StreamRecords{
+				StreamName: req.SourceStream,
+				Records:    rr,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+}
+
+// openSource opens the fixture declared in app.json for a source.
+func (s *RunService) openSource(source string) (*internal.FixtureReader, error) {
+	config, appPath := s.App()
+	fixtureFile, ok := config.Fixtures[source]
+	if !ok {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			fmt.Sprintf(
+				"no fixture file found for source %s. Ensure that the source is declared in your app.json.",
+				source,
+			),
+		)
+	}
+	return internal.OpenFixture(path.Join(appPath, fixtureFile), config.FixtureFormats[source])
+}
+
 func (s *RunService) AddDestination(_ context.Context, req *turbinev2.AddDestinationRequest) (*turbinev2.AddDestinationResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
+	if err := s.resolvePluginConfig(req.Name, req.Plugin); err != nil {
+		return nil, err
+	}
+
 	return &turbinev2.AddDestinationResponse{
 		Id: req.Name,
 	}, nil
 }
 
-func (s *RunService) WriteRecords(_ context.Context, req *turbinev2.WriteRecordsRequest) (*emptypb.Empty, error) {
+func (s *RunService) WriteRecords(ctx context.Context, req *turbinev2.WriteRecordsRequest) (*emptypb.Empty, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	internal.PrintRecords(req.DestinationID, req.StreamRecords)
+	records := make([]opencdc.Record, len(req.StreamRecords.Records))
+	for i, proto := range req.StreamRecords.Records {
+		if err := records[i].FromProto(proto); err != nil {
+			return nil, status.Error(
+				codes.InvalidArgument,
+				fmt.Sprintf("invalid record %d written to destination %s: %s", i, req.DestinationID, err),
+			)
+		}
+	}
+
+	sink, err := s.sink(req.DestinationID)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := sink.Write(ctx, records); err != nil {
+		return nil, status.Error(
+			codes.Internal,
+			fmt.Sprintf("failed to write records to destination %s: %s", req.DestinationID, err),
+		)
+	}
 
 	return empty(), nil
 }
 
-func (s *RunService) ProcessRecords(_ context.Context, req *turbinev2.ProcessRecordsRequest) (*turbinev2.ProcessRecordsResponse, error) {
+// sink returns the sink configured in app.json for a destination, creating it on first use.
+func (s *RunService) sink(destination string) (internal.Sink, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if sink, ok := s.sinks[destination]; ok {
+		return sink, nil
+	}
+
+	sink, err := internal.NewSink(destination, s.config.Destinations[destination], s.appPath)
+	if err != nil {
+		return nil, fmt.Errorf("destination %s: %w", destination, err)
+	}
+
+	if s.sinks == nil {
+		s.sinks = make(map[string]internal.Sink)
+	}
+	s.sinks[destination] = sink
+
+	return sink, nil
+}
+
+// WrittenRecords returns the records written so far to a destination using
+// the memory sink, and false for destinations using any other sink.
+func (s *RunService) WrittenRecords(destination string) ([]opencdc.Record, bool) {
+	config, _ := s.App()
+	if config.Destinations[destination].Type != app.SinkMemory {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	sink, ok := s.sinks[destination].(*internal.MemorySink)
+	s.mu.Unlock()
+
+	if !ok {
+		// Nothing was written yet.
+		return []opencdc.Record{}, true
+	}
+	return sink.Records(), true
+}
+
+func (s *RunService) ProcessRecords(ctx context.Context, req *turbinev2.ProcessRecordsRequest) (*turbinev2.ProcessRecordsResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
+	records := req.StreamRecords.Records
+
+	// Functions without an address in app.json are not running locally,
+	// records are passed through unchanged.
+	config, _ := s.App()
+	if addr, ok := config.Functions[req.Process.Name]; ok {
+		p, err := s.processor(ctx, addr)
+		if err != nil {
+			return nil, status.Error(
+				codes.Unavailable,
+				fmt.Sprintf("failed to connect to function %s at %s: %s", req.Process.Name, addr, err),
+			)
+		}
+
+		resp, err := p.client.Process(ctx, &processv2.ProcessRequest{Records: records})
+		if err != nil {
+			return nil, err
+		}
+		records = resp.Records
+	}
+
 	return &turbinev2.ProcessRecordsResponse{
 		StreamRecords: &turbinev2.StreamRecords{
 			StreamName: req.StreamRecords.StreamName,
-			Records:    req.StreamRecords.Records,
+			Records:    records,
 		},
 	}, nil
 }
+
+// processor returns the function process listening on addr, dialing it on
+// first use.
+func (s *RunService) processor(ctx context.Context, addr string) (*functionProcess, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if p, ok := s.processors[addr]; ok {
+		return p, nil
+	}
+
+	p, err := dialProcessor(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.processors == nil {
+		s.processors = make(map[string]*functionProcess)
+	}
+	s.processors[addr] = p
+
+	return p, nil
+}
+
+// Close closes the connections to the function processes.
+func (s *RunService) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.closeProcessors()
+}
+
+// closeProcessors closes the connections to the function processes, the
+// caller holds s.mu.
+func (s *RunService) closeProcessors() error {
+	var errs []error
+	for addr, p := range s.processors {
+		if err := p.conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("function at %s: %w", addr, err))
+		}
+	}
+	s.processors = nil
+
+	return errors.Join(errs...)
+}
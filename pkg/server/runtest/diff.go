@@ -0,0 +1,136 @@
+package runtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+)
+
+// DiffRecords compares records field by field and returns one line per
+// difference, or an empty string when they are equal. Lines name the record
+// and the path of the field, e.g. record 2: payload.after.category. Raw data
+// is compared as text and structured data as JSON, so that numbers compare
+// equal whatever their Go type. Metadata keys in ignore are left out.
+func DiffRecords(want, got []opencdc.Record, ignore ...string) string {
+	var lines []string
+	for i := 0; i < len(want) || i < len(got); i++ {
+		record := fmt.Sprintf("record %d", i)
+		switch {
+		case i >= len(got):
+			lines = append(lines, fmt.Sprintf("%s: missing, want %s", record, render(recordTree(want[i], ignore))))
+		case i >= len(want):
+			lines = append(lines, fmt.Sprintf("%s: unexpected %s", record, render(recordTree(got[i], ignore))))
+		default:
+			lines = diffTree(lines, record, "", recordTree(want[i], ignore), recordTree(got[i], ignore))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// recordTree returns the fields of a record as generic JSON values.
+func recordTree(r opencdc.Record, ignore []string) map[string]interface{} {
+	metadata := map[string]interface{}{}
+	for k, v := range r.Metadata {
+		metadata[k] = v
+	}
+	for _, k := range ignore {
+		delete(metadata, k)
+	}
+
+	return map[string]interface{}{
+		"position":  string(r.Position),
+		"operation": r.Operation.String(),
+		"metadata":  metadata,
+		"key":       dataTree(r.Key),
+		"payload": map[string]interface{}{
+			"before": dataTree(r.Payload.Before),
+			"after":  dataTree(r.Payload.After),
+		},
+	}
+}
+
+func dataTree(d opencdc.Data) interface{} {
+	switch d := d.(type) {
+	case nil:
+		return nil
+	case opencdc.RawData:
+		if len(d) == 0 {
+			return nil
+		}
+		return string(d)
+	case opencdc.StructuredData:
+		if len(d) == 0 {
+			return nil
+		}
+		var v interface{}
+		if err := json.Unmarshal(d.Bytes(), &v); err != nil {
+			return string(d.Bytes())
+		}
+		return v
+	default:
+		return string(d.Bytes())
+	}
+}
+
+// diffTree appends the differences between two JSON values, prefixed by the
+// record and the path of the field.
+func diffTree(lines []string, record, path string, want, got interface{}) []string {
+	wantMap, wantIsMap := want.(map[string]interface{})
+	gotMap, gotIsMap := got.(map[string]interface{})
+	if wantIsMap && gotIsMap {
+		keys := make([]string, 0, len(wantMap)+len(gotMap))
+		for k := range wantMap {
+			keys = append(keys, k)
+		}
+		for k := range gotMap {
+			if _, ok := wantMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			w, inWant := wantMap[k]
+			g, inGot := gotMap[k]
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			switch {
+			case !inGot:
+				lines = append(lines, fmt.Sprintf("%s: %s: missing, want %s", record, p, render(w)))
+			case !inWant:
+				lines = append(lines, fmt.Sprintf("%s: %s: unexpected %s", record, p, render(g)))
+			default:
+				lines = diffTree(lines, record, p, w, g)
+			}
+		}
+		return lines
+	}
+
+	wantSlice, wantIsSlice := want.([]interface{})
+	gotSlice, gotIsSlice := got.([]interface{})
+	if wantIsSlice && gotIsSlice && len(wantSlice) == len(gotSlice) {
+		for i := range wantSlice {
+			lines = diffTree(lines, record, fmt.Sprintf("%s[%d]", path, i), wantSlice[i], gotSlice[i])
+		}
+		return lines
+	}
+
+	if !reflect.DeepEqual(want, got) {
+		lines = append(lines, fmt.Sprintf("%s: %s: want %s, got %s", record, path, render(want), render(got)))
+	}
+	return lines
+}
+
+func render(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,67 @@
+package runtest
+
+import (
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffRecords(t *testing.T) {
+	record := func(after opencdc.Data, metadata opencdc.Metadata) opencdc.Record {
+		return opencdc.Record{
+			Position:  opencdc.Position("1"),
+			Operation: opencdc.OperationCreate,
+			Metadata:  metadata,
+			Key:       opencdc.RawData("key"),
+			Payload:   opencdc.Change{After: after},
+		}
+	}
+
+	tests := []struct {
+		desc   string
+		want   []opencdc.Record
+		got    []opencdc.Record
+		ignore []string
+		diff   string
+	}{
+		{
+			desc: "numbers compare equal whatever their type",
+			want: []opencdc.Record{record(opencdc.StructuredData{"id": float64(1)}, nil)},
+			got:  []opencdc.Record{record(opencdc.StructuredData{"id": 1}, opencdc.Metadata{})},
+		},
+		{
+			desc: "nested fields and metadata",
+			want: []opencdc.Record{record(
+				opencdc.StructuredData{"id": 1, "address": map[string]interface{}{"city": "Austin"}, "tags": []interface{}{"a", "b"}},
+				opencdc.Metadata{"postgres.table": "orders", "opencdc.readAt": "1"},
+			)},
+			got: []opencdc.Record{record(
+				opencdc.StructuredData{"id": 1, "address": map[string]interface{}{"city": "Boston"}, "tags": []interface{}{"a", "c"}, "note": "new"},
+				opencdc.Metadata{"opencdc.readAt": "2"},
+			)},
+			ignore: []string{"opencdc.readAt"},
+			diff: `record 0: metadata.postgres.table: missing, want "orders"` + "\n" +
+				`record 0: payload.after.address.city: want "Austin", got "Boston"` + "\n" +
+				`record 0: payload.after.note: unexpected "new"` + "\n" +
+				`record 0: payload.after.tags[1]: want "b", got "c"`,
+		},
+		{
+			desc: "raw and structured payloads",
+			want: []opencdc.Record{record(opencdc.RawData("plain"), nil)},
+			got:  []opencdc.Record{record(opencdc.StructuredData{"id": 1}, nil)},
+			diff: `record 0: payload.after: want "plain", got {"id":1}`,
+		},
+		{
+			desc: "unexpected records",
+			got:  []opencdc.Record{record(nil, nil)},
+			diff: `record 0: unexpected {"key":"key","metadata":{},"operation":"create","payload":{"after":null,"before":null},"position":"1"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.diff, DiffRecords(tc.want, tc.got, tc.ignore...))
+		})
+	}
+}
@@ -0,0 +1,240 @@
+// Package runtest provides a test harness on top of server.RunService. It
+// records what every destination received and compares it with golden files
+// kept next to the fixtures of the app, which are rewritten instead when
+// Options.Update is set, e.g. from an -update flag of the test package.
+package runtest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/meroxa/turbine-core/v2/pkg/server"
+	"github.com/meroxa/turbine-core/v2/proto/turbine/v2"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// GoldenSuffix ends the name of golden files, which are named after the
+// destination they hold the records of.
+const GoldenSuffix = ".golden.json"
+
+var _ turbinev2.ServiceServer = (*Harness)(nil)
+
+// Options configures a Harness.
+type Options struct {
+	// GoldenDir holds the golden files. It defaults to the directory of the
+	// fixtures of the app, or the "fixtures" directory of the app when it
+	// has none.
+	GoldenDir string
+	// IgnoreMetadata lists metadata keys left out of comparisons, on top of
+	// opencdc.readAt which changes on every run.
+	IgnoreMetadata []string
+	// Update makes AssertGolden rewrite the golden files rather than compare
+	// them with the records received.
+	Update bool
+}
+
+// Harness is a RunService that records the records written to every
+// destination.
+type Harness struct {
+	*server.RunService
+
+	opts Options
+
+	mu       sync.Mutex
+	received map[string][]opencdc.Record
+}
+
+func New(opts Options) *Harness {
+	return &Harness{
+		RunService: server.NewRunService(),
+		opts:       opts,
+		received:   map[string][]opencdc.Record{},
+	}
+}
+
+// Init starts a new run, the records received by previous runs are dropped.
+func (h *Harness) Init(ctx context.Context, req *turbinev2.InitRequest) (*emptypb.Empty, error) {
+	resp, err := h.RunService.Init(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.received = map[string][]opencdc.Record{}
+
+	return resp, nil
+}
+
+func (h *Harness) WriteRecords(ctx context.Context, req *turbinev2.WriteRecordsRequest) (*emptypb.Empty, error) {
+	resp, err := h.RunService.WriteRecords(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]opencdc.Record, len(req.StreamRecords.Records))
+	for i, proto := range req.StreamRecords.Records {
+		// RunService.WriteRecords already rejected invalid records.
+		_ = records[i].FromProto(proto)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.received[req.DestinationID] = append(h.received[req.DestinationID], records...)
+
+	return resp, nil
+}
+
+// Received returns the records written so far to a destination.
+func (h *Harness) Received(destination string) []opencdc.Record {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	out := make([]opencdc.Record, len(h.received[destination]))
+	copy(out, h.received[destination])
+	return out
+}
+
+// Diff compares the records written to a destination with the expected ones,
+// see DiffRecords.
+func (h *Harness) Diff(destination string, want []opencdc.Record) string {
+	return DiffRecords(want, h.Received(destination), h.ignoredMetadata()...)
+}
+
+// AssertGolden fails the test when a destination did not receive the records
+// of its golden file. With Options.Update, the golden files are rewritten
+// instead.
+func (h *Harness) AssertGolden(t testing.TB) {
+	t.Helper()
+
+	if err := h.CheckGolden(h.opts.Update); err != nil {
+		t.Error(err)
+	}
+}
+
+// CheckGolden compares the records written to every destination with their
+// golden file, and returns an error holding the differences. Destinations
+// with a golden file that received nothing are compared too. When update is
+// set, the golden files of the destinations that received records are
+// rewritten instead.
+func (h *Harness) CheckGolden(update bool) error {
+	dir := h.GoldenDir()
+
+	h.mu.Lock()
+	received := make(map[string]bool, len(h.received))
+	destinations := make([]string, 0, len(h.received))
+	for d := range h.received {
+		received[d] = true
+		destinations = append(destinations, d)
+	}
+	h.mu.Unlock()
+
+	if update {
+		sort.Strings(destinations)
+		for _, d := range destinations {
+			if err := WriteGolden(goldenFile(dir, d), h.Received(d)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*"+GoldenSuffix))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		d := strings.TrimSuffix(filepath.Base(f), GoldenSuffix)
+		if !received[d] {
+			destinations = append(destinations, d)
+		}
+	}
+	sort.Strings(destinations)
+
+	var diffs []string
+	for _, d := range destinations {
+		want, err := ReadGolden(goldenFile(dir, d))
+		if errors.Is(err, os.ErrNotExist) {
+			diffs = append(diffs, fmt.Sprintf("destination %s: no golden file %s, update the golden files to create it", d, goldenFile(dir, d)))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if diff := h.Diff(d, want); diff != "" {
+			diffs = append(diffs, fmt.Sprintf("destination %s differs from %s:\n%s", d, goldenFile(dir, d), diff))
+		}
+	}
+
+	if len(diffs) > 0 {
+		return errors.New(strings.Join(diffs, "\n"))
+	}
+	return nil
+}
+
+// GoldenDir returns the directory holding the golden files.
+func (h *Harness) GoldenDir() string {
+	if h.opts.GoldenDir != "" {
+		return h.opts.GoldenDir
+	}
+
+	config, appPath := h.App()
+
+	sources := make([]string, 0, len(config.Fixtures))
+	for s := range config.Fixtures {
+		sources = append(sources, s)
+	}
+	if len(sources) == 0 {
+		return filepath.Join(appPath, "fixtures")
+	}
+	sort.Strings(sources)
+	return filepath.Dir(filepath.Join(appPath, config.Fixtures[sources[0]]))
+}
+
+func (h *Harness) ignoredMetadata() []string {
+	return append([]string{opencdc.MetadataReadAt}, h.opts.IgnoreMetadata...)
+}
+
+func goldenFile(dir, destination string) string {
+	return filepath.Join(dir, destination+GoldenSuffix)
+}
+
+// ReadGolden reads the records of a golden file, a JSON array of records.
+func ReadGolden(file string) ([]opencdc.Record, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []opencdc.Record
+	if err := json.Unmarshal(b, &records); err != nil {
+		return nil, fmt.Errorf("failed to decode golden file %s: %w", file, err)
+	}
+	return records, nil
+}
+
+// WriteGolden writes records to a golden file as an indented JSON array.
+func WriteGolden(file string, records []opencdc.Record) error {
+	if records == nil {
+		records = []opencdc.Record{}
+	}
+	b, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(b, '\n'), 0o644)
+}
@@ -0,0 +1,124 @@
+package runtest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/conduitio/conduit-commons/opencdc"
+	"github.com/meroxa/turbine-core/v2/proto/turbine/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// run passes the records of the orders fixture to the archive destination.
+func run(t *testing.T, h *Harness, transform func(*opencdc.Record)) {
+	t.Helper()
+	ctx := context.Background()
+
+	_, err := h.Init(ctx, &turbinev2.InitRequest{
+		AppName:        "runtest",
+		ConfigFilePath: "testdata/app",
+		Language:       turbinev2.Language_GOLANG,
+	})
+	require.NoError(t, err)
+
+	resp, err := h.ReadRecords(ctx, &turbinev2.ReadRecordsRequest{SourceStream: "orders"})
+	require.NoError(t, err)
+
+	if transform != nil {
+		for i, proto := range resp.StreamRecords.Records {
+			var r opencdc.Record
+			require.NoError(t, r.FromProto(proto))
+			transform(&r)
+			require.NoError(t, r.ToProto(resp.StreamRecords.Records[i]))
+		}
+	}
+
+	_, err = h.WriteRecords(ctx, &turbinev2.WriteRecordsRequest{
+		DestinationID: "archive",
+		StreamRecords: resp.StreamRecords,
+	})
+	require.NoError(t, err)
+}
+
+func TestHarness_AssertGolden(t *testing.T) {
+	h := New(Options{})
+	run(t, h, nil)
+
+	assert.Equal(t, "testdata/app/fixtures", h.GoldenDir())
+	assert.Len(t, h.Received("archive"), 3)
+	h.AssertGolden(t)
+}
+
+func TestHarness_AssertGolden_SecondRun(t *testing.T) {
+	h := New(Options{})
+	run(t, h, nil)
+	run(t, h, nil)
+
+	assert.Len(t, h.Received("archive"), 3)
+	h.AssertGolden(t)
+}
+
+func TestHarness_AssertGolden_Update(t *testing.T) {
+	dir := t.TempDir()
+	h := New(Options{GoldenDir: dir, Update: true})
+	run(t, h, nil)
+
+	h.AssertGolden(t)
+	assert.FileExists(t, filepath.Join(dir, "archive"+GoldenSuffix))
+}
+
+func TestHarness_CheckGolden(t *testing.T) {
+	golden, err := os.ReadFile("testdata/app/fixtures/archive" + GoldenSuffix)
+	require.NoError(t, err)
+
+	t.Run("reports differences", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "archive"+GoldenSuffix), golden, 0o644))
+		require.NoError(t, WriteGolden(filepath.Join(dir, "audit"+GoldenSuffix), nil))
+		require.NoError(t, WriteGolden(filepath.Join(dir, "search"+GoldenSuffix), []opencdc.Record{{
+			Position:  opencdc.Position("1"),
+			Operation: opencdc.OperationCreate,
+		}}))
+
+		h := New(Options{GoldenDir: dir})
+		run(t, h, func(r *opencdc.Record) {
+			if r.Operation == opencdc.OperationUpdate {
+				r.Payload.After.(opencdc.StructuredData)["category"] = "Clothing"
+				r.Metadata["opencdc.readAt"] = "0"
+			}
+		})
+
+		err := h.CheckGolden(false)
+		require.Error(t, err)
+		assert.Equal(t, "destination archive differs from "+filepath.Join(dir, "archive"+GoldenSuffix)+":\n"+
+			`record 1: payload.after.category: want "Electronics Updated", got "Clothing"`+"\n"+
+			"destination search differs from "+filepath.Join(dir, "search"+GoldenSuffix)+":\n"+
+			`record 0: missing, want {"key":null,"metadata":{},"operation":"create","payload":{"after":null,"before":null},"position":"1"}`,
+			err.Error())
+	})
+
+	t.Run("reports missing golden files", func(t *testing.T) {
+		dir := t.TempDir()
+		h := New(Options{GoldenDir: dir})
+		run(t, h, nil)
+
+		err := h.CheckGolden(false)
+		require.EqualError(t, err, "destination archive: no golden file "+
+			filepath.Join(dir, "archive"+GoldenSuffix)+", update the golden files to create it")
+	})
+
+	t.Run("updates golden files", func(t *testing.T) {
+		dir := t.TempDir()
+		h := New(Options{GoldenDir: dir})
+		run(t, h, nil)
+
+		require.NoError(t, h.CheckGolden(true))
+		got, err := os.ReadFile(filepath.Join(dir, "archive"+GoldenSuffix))
+		require.NoError(t, err)
+		assert.Equal(t, string(golden), string(got))
+		require.NoError(t, h.CheckGolden(false))
+	})
+}
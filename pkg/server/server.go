@@ -4,21 +4,37 @@ package server
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"io"
 	"net"
+	"sync"
+	"time"
 
+	"github.com/meroxa/turbine-core/v2/pkg/connector"
+	"github.com/meroxa/turbine-core/v2/pkg/transport"
 	"github.com/meroxa/turbine-core/v2/proto/turbine/v2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 const (
-	ListenAddress = "localhost:50500"
+	ListenAddress = transport.DefaultAddr
+
+	// DefaultShutdownTimeout bounds how long a server waits for pending
+	// requests once its context is cancelled, before closing them.
+	DefaultShutdownTimeout = 10 * time.Second
 )
 
 type Server interface {
-	Run(context.Context)
-	RunAddr(context.Context, string)
+	Run(context.Context) error
+	RunAddr(context.Context, string) error
+	Addr() net.Addr
+	Ready() <-chan struct{}
+	ListenErr() error
 	GracefulStop()
 }
 
@@ -26,37 +42,233 @@ var _ Server = (*TurbineCoreServer)(nil)
 
 type TurbineCoreServer struct {
 	*grpc.Server
+
+	health *health.Server
+	// service is closed once the server stopped, when it is an io.Closer.
+	service turbinev2.ServiceServer
+
+	// ShutdownTimeout overrides DefaultShutdownTimeout when positive.
+	ShutdownTimeout time.Duration
+
+	// err holds a configuration error, returned once the server runs.
+	err error
+
+	mu        sync.Mutex
+	addr      net.Addr
+	listenErr error
+	ready     chan struct{}
+	readyOnce sync.Once
 }
 
+// NewRunServer returns a server for a RunService, using TLS when it is set in
+// the environment, see transport.TLSConfigFromEnv.
 func NewRunServer() *TurbineCoreServer {
-	s := grpc.NewServer()
-	turbinev2.RegisterServiceServer(s, NewRunService())
-	return &TurbineCoreServer{Server: s}
+	return newTurbineCoreServer(NewRunService())
 }
 
+// NewSpecBuilderServer returns a server for a SpecBuilderService validating
+// plugin configs against connector.Builtin(), using TLS when it is set in the
+// environment, see transport.TLSConfigFromEnv.
 func NewSpecBuilderServer() *TurbineCoreServer {
-	s := grpc.NewServer()
-	turbinev2.RegisterServiceServer(s, NewSpecBuilderService())
-	return &TurbineCoreServer{Server: s}
+	svc := NewSpecBuilderService()
+	svc.Plugins = connector.Builtin()
+	return newTurbineCoreServer(svc)
 }
 
 func NewRecordServer() *TurbineCoreServer {
 	return NewSpecBuilderServer()
 }
 
-func (s *TurbineCoreServer) Run(ctx context.Context) {
-	s.RunAddr(ctx, ListenAddress)
+type options struct {
+	tls transport.TLSConfig
+}
+
+type Option func(*options)
+
+// WithTLS serves over TLS, and mTLS when a certificate authority is set, see
+// transport.TLSConfig.
+func WithTLS(cfg transport.TLSConfig) Option {
+	return func(o *options) {
+		o.tls = cfg
+	}
+}
+
+// NewServer returns a server for a service, such as a RunService or a
+// SpecBuilderService, configured with the given options.
+func NewServer(svc turbinev2.ServiceServer, opts ...Option) (*TurbineCoreServer, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	creds, err := o.tls.ServerCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	s, h := newGRPCServer(svc, grpc.Creds(creds))
+	return &TurbineCoreServer{Server: s, health: h, service: svc}, nil
 }
 
-func (s *TurbineCoreServer) RunAddr(ctx context.Context, addr string) {
-	listener, err := net.Listen("tcp", addr)
+// newTurbineCoreServer returns a server for a service configured from the
+// environment, like the clients dialed with client.DialEnv. Invalid TLS
+// settings are reported when the server runs.
+func newTurbineCoreServer(svc turbinev2.ServiceServer) *TurbineCoreServer {
+	s, err := NewServer(svc, WithTLS(transport.TLSConfigFromEnv()))
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		gs, h := newGRPCServer(svc)
+		return &TurbineCoreServer{Server: gs, health: h, service: svc, err: err}
 	}
+	return s
+}
+
+// newGRPCServer registers a service along with the gRPC health service and
+// server reflection. The service reports NOT_SERVING until Init completed.
+func newGRPCServer(svc turbinev2.ServiceServer, opts ...grpc.ServerOption) (*grpc.Server, *health.Server) {
+	h := health.NewServer()
+	h.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	h.SetServingStatus(turbinev2.Service_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+
+	s := grpc.NewServer(append(opts, grpc.ChainUnaryInterceptor(servingAfterInit(h)))...)
+	turbinev2.RegisterServiceServer(s, svc)
+	healthpb.RegisterHealthServer(s, h)
+	reflection.Register(s)
+
+	return s, h
+}
+
+// servingAfterInit marks the service as SERVING once Init succeeded.
+func servingAfterInit(h *health.Server) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil && info.FullMethod == turbinev2.Service_Init_FullMethodName {
+			h.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+			h.SetServingStatus(turbinev2.Service_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+		}
+		return resp, err
+	}
+}
+
+// Run serves on the address set in the environment, or ListenAddress, see
+// transport.AddrFromEnv.
+func (s *TurbineCoreServer) Run(ctx context.Context) error {
+	return s.RunAddr(ctx, transport.AddrFromEnv())
+}
+
+// RunAddr listens on addr, a host:port TCP address or a unix:// socket path,
+// and serves until ctx is cancelled, then stops gracefully and returns nil.
+// Listen and serve failures are returned. Use Ready and Addr to learn when
+// and where the server listens, e.g. when addr has port 0, and ListenErr to
+// learn whether it failed to.
+func (s *TurbineCoreServer) RunAddr(ctx context.Context, addr string) error {
+	listener, err := s.listen(addr)
+
+	s.mu.Lock()
+	if err != nil {
+		s.listenErr = err
+	} else {
+		s.addr = listener.Addr()
+	}
+	s.mu.Unlock()
+	ready := s.readyChan()
+	s.readyOnce.Do(func() { close(ready) })
+
+	if err != nil {
+		return err
+	}
+
+	served := make(chan struct{})
+	defer close(served)
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.shutdown()
+		case <-served:
+		}
+	}()
+
+	if err := s.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return errors.Join(fmt.Errorf("failed to serve: %w", err), closeService(s.service))
+	}
+	return closeService(s.service)
+}
+
+func (s *TurbineCoreServer) listen(addr string) (net.Listener, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	listener, err := transport.Listen(addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	return listener, nil
+}
+
+// closeService releases the resources held by a service, such as the
+// connections of a RunService to function processes.
+func closeService(svc turbinev2.ServiceServer) error {
+	if c, ok := svc.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// shutdown stops the server gracefully, closing pending requests once the
+// shutdown timeout is reached.
+func (s *TurbineCoreServer) shutdown() {
+	timeout := s.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = DefaultShutdownTimeout
+	}
+
+	if s.health != nil {
+		// Let health watchers know before connections are drained.
+		s.health.Shutdown()
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(timeout):
+		s.Stop()
+	}
+}
+
+// Addr returns the address the server listens on, or nil before it is ready.
+func (s *TurbineCoreServer) Addr() net.Addr {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addr
+}
+
+// Ready returns a channel that is closed once the server listens, or once it
+// failed to, in which case ListenErr returns why.
+func (s *TurbineCoreServer) Ready() <-chan struct{} {
+	return s.readyChan()
+}
+
+// ListenErr returns the error which kept the server from listening, or nil.
+// It is only set once Ready is closed.
+func (s *TurbineCoreServer) ListenErr() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.listenErr
+}
+
+func (s *TurbineCoreServer) readyChan() chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if err := s.Serve(listener); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	if s.ready == nil {
+		s.ready = make(chan struct{})
 	}
+	return s.ready
 }
 
 func empty() *emptypb.Empty {
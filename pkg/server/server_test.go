@@ -0,0 +1,374 @@
+package server
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/meroxa/turbine-core/v2/pkg/client"
+	"github.com/meroxa/turbine-core/v2/pkg/transport"
+	"github.com/meroxa/turbine-core/v2/proto/turbine/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestTurbineCoreServer_RunAddr(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := NewSpecBuilderServer()
+	assert.Nil(t, s.Addr())
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- s.RunAddr(ctx, "localhost:0")
+	}()
+
+	select {
+	case <-s.Ready():
+	case err := <-errC:
+		t.Fatalf("server stopped before being ready: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server not ready")
+	}
+
+	addr, ok := s.Addr().(*net.TCPAddr)
+	require.True(t, ok)
+	require.NotZero(t, addr.Port)
+
+	c, err := client.DialTimeout(addr.String(), 5*time.Second)
+	require.NoError(t, err)
+	defer c.Close()
+
+	_, err = c.Init(ctx, &turbinev2.InitRequest{
+		AppName:        "app",
+		ConfigFilePath: "path/to/app",
+		Language:       turbinev2.Language_GOLANG,
+		GitSHA:         "gitsha",
+		TurbineVersion: "0.1.0",
+	})
+	require.NoError(t, err)
+
+	cancel()
+	select {
+	case err := <-errC:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop")
+	}
+}
+
+func TestTurbineCoreServer_RunAddr_ListenError(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	defer lis.Close()
+
+	s := NewRunServer()
+	err = s.RunAddr(context.Background(), lis.Addr().String())
+	require.ErrorContains(t, err, "failed to listen on "+lis.Addr().String())
+
+	// Callers waiting for the server are released along with the error.
+	select {
+	case <-s.Ready():
+	default:
+		t.Fatal("server is not ready")
+	}
+	require.Equal(t, err, s.ListenErr())
+	require.Nil(t, s.Addr())
+}
+
+func TestTurbineCoreServer_ShutdownTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	svc := &blockingService{started: make(chan struct{})}
+	s := newTurbineCoreServer(svc)
+	s.ShutdownTimeout = 50 * time.Millisecond
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- s.RunAddr(ctx, "localhost:0")
+	}()
+	<-s.Ready()
+
+	c, err := client.DialTimeout(s.Addr().String(), 5*time.Second)
+	require.NoError(t, err)
+	defer c.Close()
+
+	go func() {
+		_, _ = c.AddSource(context.Background(), &turbinev2.AddSourceRequest{Name: "source"})
+	}()
+	<-svc.started
+
+	// The pending request never returns, the server gives up on it.
+	cancel()
+	select {
+	case err := <-errC:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not stop")
+	}
+}
+
+type blockingService struct {
+	turbinev2.UnimplementedServiceServer
+	started chan struct{}
+}
+
+func (s *blockingService) AddSource(ctx context.Context, _ *turbinev2.AddSourceRequest) (*turbinev2.AddSourceResponse, error) {
+	close(s.started)
+	// Only Stop cancels the context of pending requests.
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestTurbineCoreServer_Health(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := EmbedSpecBuilderService(ctx)
+	require.NoError(t, err)
+	defer c.Close()
+
+	hc := healthpb.NewHealthClient(c.ClientConn)
+	servingStatus := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := hc.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.Status
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus("turbine.v2.Service"))
+
+	// A failed Init leaves the service not serving.
+	_, err = c.Init(ctx, &turbinev2.InitRequest{})
+	require.Error(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(""))
+
+	_, err = c.Init(ctx, &turbinev2.InitRequest{
+		AppName:        "app",
+		ConfigFilePath: "path/to/app",
+		Language:       turbinev2.Language_GOLANG,
+		GitSHA:         "gitsha",
+		TurbineVersion: "0.1.0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus("turbine.v2.Service"))
+}
+
+func TestTurbineCoreServer_Reflection(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := EmbedRunService(ctx)
+	require.NoError(t, err)
+	defer c.Close()
+
+	stream, err := reflectionpb.NewServerReflectionClient(c.ClientConn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+
+	var services []string
+	for _, s := range resp.GetListServicesResponse().Service {
+		services = append(services, s.Name)
+	}
+	assert.Contains(t, services, "turbine.v2.Service")
+	assert.Contains(t, services, "grpc.health.v1.Health")
+}
+
+// serve runs a server until the test ends and returns the address it listens on.
+func serve(t *testing.T, s *TurbineCoreServer, addr string) net.Addr {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- s.RunAddr(ctx, addr)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-errC)
+	})
+
+	select {
+	case <-s.Ready():
+		return s.Addr()
+	case err := <-errC:
+		t.Fatalf("server stopped before being ready: %v", err)
+		return nil
+	}
+}
+
+func initApp(ctx context.Context, c client.Client) error {
+	_, err := c.Init(ctx, &turbinev2.InitRequest{
+		AppName:        "app",
+		ConfigFilePath: "path/to/app",
+		Language:       turbinev2.Language_GOLANG,
+		GitSHA:         "gitsha",
+		TurbineVersion: "0.1.0",
+	})
+	return err
+}
+
+func TestTurbineCoreServer_UnixSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "turbine")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	addr := transport.UnixScheme + filepath.Join(dir, "core.sock")
+
+	got := serve(t, NewSpecBuilderServer(), addr)
+	assert.Equal(t, "unix", got.Network())
+
+	t.Setenv(transport.EnvAddr, addr)
+	c, err := client.DialEnv(context.Background())
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, initApp(context.Background(), c))
+}
+
+func TestTurbineCoreServer_MutualTLS(t *testing.T) {
+	ctx := context.Background()
+	certs := writeTestCerts(t)
+
+	s, err := NewServer(NewSpecBuilderService(), WithTLS(transport.TLSConfig{
+		CertFile: certs.serverCert,
+		KeyFile:  certs.serverKey,
+		CAFile:   certs.ca,
+	}))
+	require.NoError(t, err)
+	addr := serve(t, s, "localhost:0").String()
+
+	t.Run("accepts clients with a certificate", func(t *testing.T) {
+		c, err := client.DialContext(ctx, addr, client.WithTLS(transport.TLSConfig{
+			CertFile:   certs.clientCert,
+			KeyFile:    certs.clientKey,
+			CAFile:     certs.ca,
+			ServerName: "localhost",
+		}))
+		require.NoError(t, err)
+		defer c.Close()
+
+		require.NoError(t, initApp(ctx, c))
+	})
+
+	t.Run("rejects clients without a certificate", func(t *testing.T) {
+		c, err := client.DialContext(ctx, addr, client.WithTLS(transport.TLSConfig{
+			CAFile:     certs.ca,
+			ServerName: "localhost",
+		}))
+		require.NoError(t, err)
+		defer c.Close()
+
+		require.Equal(t, codes.Unavailable, status.Code(initApp(ctx, c)))
+	})
+
+	t.Run("rejects insecure clients", func(t *testing.T) {
+		c, err := client.DialContext(ctx, addr)
+		require.NoError(t, err)
+		defer c.Close()
+
+		require.Equal(t, codes.Unavailable, status.Code(initApp(ctx, c)))
+	})
+}
+
+func TestTurbineCoreServer_TLSFromEnv(t *testing.T) {
+	ctx := context.Background()
+	certs := writeTestCerts(t)
+
+	t.Setenv(transport.EnvTLSCert, certs.serverCert)
+	t.Setenv(transport.EnvTLSKey, certs.serverKey)
+	t.Setenv(transport.EnvTLSCA, certs.ca)
+	addr := serve(t, NewSpecBuilderServer(), "localhost:0").String()
+
+	t.Setenv(transport.EnvAddr, addr)
+	t.Setenv(transport.EnvTLSCert, certs.clientCert)
+	t.Setenv(transport.EnvTLSKey, certs.clientKey)
+	t.Setenv(transport.EnvTLSServerName, "localhost")
+	c, err := client.DialEnv(ctx)
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, initApp(ctx, c))
+}
+
+func TestTurbineCoreServer_TLSFromEnv_Invalid(t *testing.T) {
+	t.Setenv(transport.EnvTLSCA, "ca.pem")
+
+	err := NewRunServer().RunAddr(context.Background(), "localhost:0")
+	require.EqualError(t, err, "a TLS server requires a certificate and a key")
+}
+
+type testCerts struct {
+	ca, serverCert, serverKey, clientCert, clientKey string
+}
+
+// writeTestCerts writes a certificate authority and the server and client
+// certificates it signed.
+func writeTestCerts(t *testing.T) testCerts {
+	t.Helper()
+	dir := t.TempDir()
+
+	write := func(name, typ string, der []byte) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600))
+		return file
+	}
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "turbine test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	leaf := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{"localhost"},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, caCert, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+		return write(name+".pem", "CERTIFICATE", der), write(name+"-key.pem", "EC PRIVATE KEY", keyDER)
+	}
+
+	certs := testCerts{ca: write("ca.pem", "CERTIFICATE", caDER)}
+	certs.serverCert, certs.serverKey = leaf("server", 2, x509.ExtKeyUsageServerAuth)
+	certs.clientCert, certs.clientKey = leaf("client", 3, x509.ExtKeyUsageClientAuth)
+	return certs
+}
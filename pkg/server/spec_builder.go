@@ -2,35 +2,107 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/meroxa/turbine-core/v2/pkg/app"
+	"github.com/meroxa/turbine-core/v2/pkg/client"
+	"github.com/meroxa/turbine-core/v2/pkg/connector"
 	"github.com/meroxa/turbine-core/v2/pkg/ir"
 	"github.com/meroxa/turbine-core/v2/proto/turbine/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// DefaultSessionTTL is how long a recording session is kept without being used.
+const DefaultSessionTTL = 30 * time.Minute
+
 var _ turbinev2.ServiceServer = (*SpecBuilderService)(nil)
 
+// SpecBuilderService records the apps built by the SDKs into deployment
+// specs. Every Init starts a new recording session, identified by the
+// client.SessionIDKey metadata of the calls. Calls without a session ID are
+// recorded in the session of the last Init made without one, which keeps
+// clients unaware of sessions working one app at a time.
+//
+// Clients unaware of sessions cannot record concurrently: an Init made without
+// a session ID takes the default session over, and the calls of a client
+// which ignores the session ID returned by its own Init are then recorded in
+// the newer recording. Such clients must use client.InitSession, or their own
+// session ID, to record several apps at once.
 type SpecBuilderService struct {
 	turbinev2.UnimplementedServiceServer
 
-	spec *ir.DeploymentSpec
-	// resources []*turbinev2.Resource
+	// SessionTTL is how long an idle session is kept, DefaultSessionTTL
+	// when zero.
+	SessionTTL time.Duration
+
+	// Plugins validates the plugin configs of the added connectors, e.g.
+	// connector.Builtin() along with local specifications added with
+	// Plugins.Load, as NewSpecBuilderServer does. Configs are not validated
+	// when nil, the default. Unknown parameters are sent back as client.WarningKey
+	// header metadata rather than rejected.
+	Plugins *connector.Registry
+
+	mu        sync.Mutex
+	sessions  map[string]*session
+	defaultID string
+	now       func() time.Time
+}
+
+// session is a single recording, its spec is guarded by mu.
+type session struct {
+	mu sync.Mutex
+
+	id               string
+	spec             *ir.DeploymentSpec
+	appName          string
+	appPath          string
+	deterministicIDs bool
+
+	createdAt time.Time
+	lastUsed  time.Time
 }
 
+// idNamespace is the root namespace of the deterministic resource IDs,
+// each app derives its own namespace from it.
+var idNamespace = uuid.MustParse("5f0c1a8e-3b8c-4d8e-9a3e-6d1f4b2c7e90")
+
 func NewSpecBuilderService() *SpecBuilderService {
 	return &SpecBuilderService{
-		spec: &ir.DeploymentSpec{},
+		sessions: make(map[string]*session),
+		now:      time.Now,
 	}
 }
 
-func (s *SpecBuilderService) Init(_ context.Context, req *turbinev2.InitRequest) (*emptypb.Empty, error) {
+func (s *SpecBuilderService) Init(ctx context.Context, req *turbinev2.InitRequest) (*emptypb.Empty, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	s.spec.Definition = ir.DefinitionSpec{
+	// A session ID given by the client restarts that session, otherwise a
+	// new default session is started.
+	id := sessionID(ctx)
+	isDefault := id == ""
+	if isDefault {
+		id = uuid.New().String()
+	}
+
+	s.mu.Lock()
+	s.expire()
+	sess := s.newSession(id)
+	sess.spec.Definition = ir.DefinitionSpec{
 		GitSha: req.GetGitSHA(),
 		Metadata: ir.MetadataSpec{
 			Turbine: ir.TurbineSpec{
@@ -40,29 +112,245 @@ func (s *SpecBuilderService) Init(_ context.Context, req *turbinev2.InitRequest)
 			SpecVersion: ir.LatestSpecVersion,
 		},
 	}
+	sess.appName = req.AppName
+	sess.appPath = req.ConfigFilePath
+	sess.deterministicIDs = req.DeterministicIDs
+	if isDefault {
+		s.defaultID = id
+	}
+	s.mu.Unlock()
+
+	if stream := grpc.ServerTransportStreamFromContext(ctx); stream != nil {
+		if err := stream.SetHeader(metadata.Pairs(client.SessionIDKey, id)); err != nil {
+			return nil, err
+		}
+	}
 	return empty(), nil
 }
 
-func (s *SpecBuilderService) AddSource(_ context.Context, req *turbinev2.AddSourceRequest) (*turbinev2.AddSourceResponse, error) {
+// ListSessions returns the sessions that have not expired, oldest first.
+func (s *SpecBuilderService) ListSessions(_ context.Context, _ *emptypb.Empty) (*turbinev2.ListSessionsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	resp := &turbinev2.ListSessionsResponse{}
+	for _, sess := range s.sessions {
+		resp.Sessions = append(resp.Sessions, &turbinev2.Session{
+			Id:         sess.id,
+			AppName:    sess.appName,
+			CreatedAt:  timestamppb.New(sess.createdAt),
+			LastUsedAt: timestamppb.New(sess.lastUsed),
+		})
+	}
+	sort.Slice(resp.Sessions, func(i, j int) bool {
+		a, b := resp.Sessions[i], resp.Sessions[j]
+		if !a.CreatedAt.AsTime().Equal(b.CreatedAt.AsTime()) {
+			return a.CreatedAt.AsTime().Before(b.CreatedAt.AsTime())
+		}
+		return a.Id < b.Id
+	})
+	return resp, nil
+}
+
+// session returns the session of the call, see SpecBuilderService. The
+// default session is started when missing, as calls were recorded without
+// Init before sessions existed. Calls without a session ID always get the
+// session of the latest Init without one, even when another client started it.
+func (s *SpecBuilderService) session(ctx context.Context) (*session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+
+	id := sessionID(ctx)
+	if id == "" {
+		if _, ok := s.sessions[s.defaultID]; !ok {
+			s.defaultID = uuid.New().String()
+			s.newSession(s.defaultID)
+		}
+		id = s.defaultID
+	}
+
+	sess, ok := s.sessions[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "session %q not found, it may have expired", id)
+	}
+	sess.lastUsed = s.clock()
+	return sess, nil
+}
+
+// newSession starts an empty session, replacing any session with the same
+// ID. The caller must hold s.mu.
+func (s *SpecBuilderService) newSession(id string) *session {
+	if s.sessions == nil {
+		s.sessions = make(map[string]*session)
+	}
+	now := s.clock()
+	sess := &session{
+		id:        id,
+		spec:      &ir.DeploymentSpec{},
+		createdAt: now,
+		lastUsed:  now,
+	}
+	s.sessions[id] = sess
+	return sess
+}
+
+// expire removes the sessions idle for longer than the TTL. The caller must
+// hold s.mu.
+func (s *SpecBuilderService) expire() {
+	ttl := s.SessionTTL
+	if ttl == 0 {
+		ttl = DefaultSessionTTL
+	}
+	now := s.clock()
+	for id, sess := range s.sessions {
+		if now.Sub(sess.lastUsed) > ttl {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+func (s *SpecBuilderService) clock() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+	return s.now()
+}
+
+// sessionID returns the session ID carried in the metadata of the call.
+func sessionID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if ids := md.Get(client.SessionIDKey); len(ids) > 0 {
+		return ids[0]
+	}
+	return ""
+}
+
+// newID returns a random UUID, or when deterministic IDs are enabled a UUID
+// derived from the app name and the given resource kind, name and position.
+func (s *session) newID(kind, name, position string) string {
+	if !s.deterministicIDs {
+		return uuid.New().String()
+	}
+	ns := uuid.NewSHA1(idNamespace, []byte(s.appName))
+	return uuid.NewSHA1(ns, []byte(kind+"/"+name+"/"+position)).String()
+}
+
+// occurrence returns how many connectors of the given type and name were
+// already recorded, telling apart connectors sharing a name.
+func (s *session) occurrence(t ir.DirectionType, name string) string {
+	n := 0
+	for _, c := range s.spec.Connectors {
+		if c.PluginType == t && c.Name == name {
+			n++
+		}
+	}
+	return strconv.Itoa(n)
+}
+
+// functionOccurrence returns how many functions of the given name already
+// process the given stream, telling apart a function applied twice to it.
+func (s *session) functionOccurrence(name, stream string) string {
+	functions := make(map[string]bool)
+	for _, f := range s.spec.Functions {
+		if f.Name == name {
+			functions[f.UUID] = true
+		}
+	}
+
+	n := 0
+	for _, st := range s.spec.Streams {
+		if st.FromUUID == stream && functions[st.ToUUID] {
+			n++
+		}
+	}
+	return stream + "/" + strconv.Itoa(n)
+}
+
+func (s *SpecBuilderService) AddSource(ctx context.Context, req *turbinev2.AddSourceRequest) (*turbinev2.AddSourceResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
+	if err := s.validatePluginConfig(ctx, req.Name, ir.PluginSource, req.Plugin); err != nil {
+		return nil, err
+	}
+
+	sess, err := s.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
 
 	c := ir.ConnectorSpec{
-		UUID:         uuid.New().String(),
+		UUID:         sess.newID(string(ir.PluginSource), req.Name, sess.occurrence(ir.PluginSource, req.Name)),
 		Name:         req.Name,
 		PluginType:   ir.PluginSource,
 		PluginName:   req.Plugin.Name,
 		PluginConfig: req.Plugin.Config,
 	}
 
-	if err := s.spec.AddSource(&c); err != nil {
+	if err := sess.spec.AddSource(&c); err != nil {
 		return nil, err
 	}
 
 	return &turbinev2.AddSourceResponse{StreamName: c.UUID}, nil
 }
 
+// validatePluginConfig checks a plugin config against its specification, the
+// violations are detailed as BadRequest field violations of plugin.config.
+// Configs with warnings only are accepted, the warnings are sent back as
+// header metadata.
+func (s *SpecBuilderService) validatePluginConfig(ctx context.Context, name string, t ir.DirectionType, plugin *turbinev2.Plugin) error {
+	if s.Plugins == nil {
+		return nil
+	}
+
+	err := s.Plugins.Validate(plugin.Name, t, plugin.Config)
+	var cfgErr *connector.ConfigError
+	if !errors.As(err, &cfgErr) {
+		return err
+	}
+	if !cfgErr.HasErrors() {
+		return sendWarnings(ctx, t, name, cfgErr.Violations)
+	}
+
+	br := &errdetails.BadRequest{}
+	for _, v := range cfgErr.Violations {
+		field := "plugin.name"
+		if v.Parameter != "" {
+			field = "plugin.config." + v.Parameter
+		}
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: v.Message,
+		})
+	}
+	st := status.New(codes.InvalidArgument, fmt.Sprintf("%s %q: %s", t, name, err))
+	if withDetails, err := st.WithDetails(br); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// sendWarnings sets the warnings of a connector as header metadata of the
+// call, when it is served over gRPC.
+func sendWarnings(ctx context.Context, t ir.DirectionType, name string, warnings []connector.Violation) error {
+	if grpc.ServerTransportStreamFromContext(ctx) == nil {
+		return nil
+	}
+
+	md := metadata.MD{}
+	for _, w := range warnings {
+		md.Append(client.WarningKey, fmt.Sprintf("%s %q: %s", t, name, w.Message))
+	}
+	return grpc.SetHeader(ctx, md)
+}
+
 func (s *SpecBuilderService) ReadRecords(_ context.Context, req *turbinev2.ReadRecordsRequest) (*turbinev2.ReadRecordsResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
@@ -75,20 +363,42 @@ func (s *SpecBuilderService) ReadRecords(_ context.Context, req *turbinev2.ReadR
 	}, nil
 }
 
-func (s *SpecBuilderService) AddDestination(_ context.Context, req *turbinev2.AddDestinationRequest) (*turbinev2.AddDestinationResponse, error) {
+func (s *SpecBuilderService) ReadRecordsStream(req *turbinev2.ReadRecordsStreamRequest, stream turbinev2.Service_ReadRecordsStreamServer) error {
 	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	return stream.Send(&turbinev2.ReadRecordsResponse{
+		StreamRecords: &turbinev2.StreamRecords{
+			StreamName: req.SourceStream,
+		},
+	})
+}
+
+func (s *SpecBuilderService) AddDestination(ctx context.Context, req *turbinev2.AddDestinationRequest) (*turbinev2.AddDestinationResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.validatePluginConfig(ctx, req.Name, ir.PluginDestination, req.Plugin); err != nil {
+		return nil, err
+	}
+
+	sess, err := s.session(ctx)
+	if err != nil {
 		return nil, err
 	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
 
 	c := ir.ConnectorSpec{
-		UUID:         uuid.New().String(),
+		UUID:         sess.newID(string(ir.PluginDestination), req.Name, sess.occurrence(ir.PluginDestination, req.Name)),
 		Name:         req.Name,
 		PluginType:   ir.PluginDestination,
 		PluginName:   req.Plugin.Name,
 		PluginConfig: req.Plugin.Config,
 	}
 
-	if err := s.spec.AddDestination(&c); err != nil {
+	if err := sess.spec.AddDestination(&c); err != nil {
 		return nil, err
 	}
 
@@ -97,13 +407,20 @@ func (s *SpecBuilderService) AddDestination(_ context.Context, req *turbinev2.Ad
 	}, nil
 }
 
-func (s *SpecBuilderService) WriteRecords(_ context.Context, req *turbinev2.WriteRecordsRequest) (*emptypb.Empty, error) {
+func (s *SpecBuilderService) WriteRecords(ctx context.Context, req *turbinev2.WriteRecordsRequest) (*emptypb.Empty, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	if err := s.spec.AddStream(&ir.StreamSpec{
-		UUID:     uuid.New().String(),
+	sess, err := s.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if err := sess.spec.AddStream(&ir.StreamSpec{
+		UUID:     sess.newID("stream", req.StreamRecords.StreamName, req.DestinationID),
 		FromUUID: req.StreamRecords.StreamName,
 		ToUUID:   req.DestinationID,
 		Name:     req.StreamRecords.StreamName + "_" + req.DestinationID,
@@ -114,21 +431,29 @@ func (s *SpecBuilderService) WriteRecords(_ context.Context, req *turbinev2.Writ
 	return empty(), nil
 }
 
-func (s *SpecBuilderService) ProcessRecords(_ context.Context, req *turbinev2.ProcessRecordsRequest) (*turbinev2.ProcessRecordsResponse, error) {
+func (s *SpecBuilderService) ProcessRecords(ctx context.Context, req *turbinev2.ProcessRecordsRequest) (*turbinev2.ProcessRecordsResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
+	sess, err := s.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	name := strings.ToLower(req.Process.Name)
 	f := ir.FunctionSpec{
-		UUID: uuid.New().String(),
-		Name: strings.ToLower(req.Process.Name),
+		UUID: sess.newID("function", name, sess.functionOccurrence(name, req.StreamRecords.StreamName)),
+		Name: name,
 	}
-	if err := s.spec.AddFunction(&f); err != nil {
+	if err := sess.spec.AddFunction(&f); err != nil {
 		return nil, err
 	}
 
-	if err := s.spec.AddStream(&ir.StreamSpec{
-		UUID:     uuid.New().String(),
+	if err := sess.spec.AddStream(&ir.StreamSpec{
+		UUID:     sess.newID("stream", req.StreamRecords.StreamName, f.UUID),
 		FromUUID: req.StreamRecords.StreamName,
 		ToUUID:   f.UUID,
 		Name:     req.StreamRecords.StreamName + "_" + f.UUID,
@@ -144,23 +469,82 @@ func (s *SpecBuilderService) ProcessRecords(_ context.Context, req *turbinev2.Pr
 	}, nil
 }
 
-func (s *SpecBuilderService) GetSpec(_ context.Context, req *turbinev2.GetSpecRequest) (*turbinev2.GetSpecResponse, error) {
+func (s *SpecBuilderService) RegisterSecret(ctx context.Context, req *turbinev2.RegisterSecretRequest) (*emptypb.Empty, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	sess, err := s.session(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+
+	if err := sess.spec.AddSecret(req.Name, ir.SecretSpec{Description: req.Description}); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return empty(), nil
+}
+
+func (s *SpecBuilderService) GetSpec(ctx context.Context, req *turbinev2.GetSpecRequest) (*turbinev2.GetSpecResponse, error) {
 	if err := req.Validate(); err != nil {
 		return nil, err
 	}
 
-	if err := s.spec.SetImageForFunctions(req.Image); err != nil {
+	sess, err := s.session(ctx)
+	if err != nil {
 		return nil, err
 	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
 
-	if _, err := s.spec.BuildDAG(); err != nil {
+	if err := sess.spec.SetImageForFunctions(req.Image); err != nil {
 		return nil, err
 	}
 
-	spec, err := s.spec.Marshal()
+	if _, err := sess.spec.BuildDAG(); err != nil {
+		return nil, err
+	}
+
+	deploymentSpec := sess.spec
+	if req.Profile != "" {
+		vars, err := sess.profile(req.Profile)
+		if err != nil {
+			return nil, err
+		}
+		if deploymentSpec, err = deploymentSpec.ResolvePlaceholders(vars); err != nil {
+			return nil, status.Error(
+				codes.InvalidArgument,
+				fmt.Sprintf("profile %s: %s", req.Profile, err),
+			)
+		}
+	}
+
+	spec, err := deploymentSpec.Marshal()
 	if err != nil {
 		return nil, err
 	}
 
 	return &turbinev2.GetSpecResponse{Spec: spec}, nil
 }
+
+// profile returns the variables of a profile of the app.json of the session.
+func (s *session) profile(name string) (map[string]string, error) {
+	config, err := app.ReadConfig(s.appName, s.appPath)
+	if err != nil {
+		return nil, status.Error(
+			codes.FailedPrecondition,
+			fmt.Sprintf("failed to read the profiles of app %s: %s", s.appName, err),
+		)
+	}
+
+	vars, ok := config.Profiles[name]
+	if !ok {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			fmt.Sprintf("profile %s is not defined in app.json", name),
+		)
+	}
+	return vars, nil
+}
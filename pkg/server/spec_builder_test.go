@@ -4,13 +4,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/conduitio/conduit-commons/proto/opencdc/v1"
 	"github.com/google/uuid"
+	"github.com/meroxa/turbine-core/v2/pkg/client"
+	"github.com/meroxa/turbine-core/v2/pkg/connector"
 	"github.com/meroxa/turbine-core/v2/pkg/ir"
 	"github.com/meroxa/turbine-core/v2/proto/turbine/v2"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func TestInit(t *testing.T) {
@@ -79,9 +92,9 @@ func TestInit(t *testing.T) {
 			if test.want == nil {
 				require.Nil(t, err)
 				require.Equal(t, empty(), res)
-				require.Equal(t, test.spec.Functions, s.spec.Functions)
-				require.Equal(t, test.spec.Connectors, s.spec.Connectors)
-				require.Equal(t, test.spec.Streams, s.spec.Streams)
+				require.Equal(t, test.spec.Functions, testSpec(s).Functions)
+				require.Equal(t, test.spec.Connectors, testSpec(s).Connectors)
+				require.Equal(t, test.spec.Streams, testSpec(s).Streams)
 			} else {
 				require.ErrorContains(t, err, test.want.Error())
 			}
@@ -137,10 +150,10 @@ func TestAddSource(t *testing.T) {
 				require.EqualError(t, err, test.errMsg)
 			} else {
 				require.Nil(t, err)
-				require.NotEmpty(t, s.spec.Connectors)
-				require.Equal(t, s.spec.Connectors[0].Name, test.req.Name)
-				require.Equal(t, s.spec.Connectors[0].UUID, res.StreamName)
-				require.Equal(t, s.spec.Connectors[0].PluginType, ir.PluginSource)
+				require.NotEmpty(t, testSpec(s).Connectors)
+				require.Equal(t, testSpec(s).Connectors[0].Name, test.req.Name)
+				require.Equal(t, testSpec(s).Connectors[0].UUID, res.StreamName)
+				require.Equal(t, testSpec(s).Connectors[0].PluginType, ir.PluginSource)
 			}
 		})
 	}
@@ -216,9 +229,9 @@ func TestAddDestination(t *testing.T) {
 				require.EqualError(t, err, test.errMsg)
 			} else {
 				require.NoError(t, err)
-				require.NotEmpty(t, s.spec.Connectors)
-				require.Equal(t, s.spec.Connectors[0].Name, test.req.Name)
-				require.Equal(t, s.spec.Connectors[0].PluginType, ir.PluginDestination)
+				require.NotEmpty(t, testSpec(s).Connectors)
+				require.Equal(t, testSpec(s).Connectors[0].Name, test.req.Name)
+				require.Equal(t, testSpec(s).Connectors[0].PluginType, ir.PluginDestination)
 			}
 		})
 	}
@@ -284,10 +297,10 @@ func TestWriteRecords(t *testing.T) {
 				require.ErrorContains(t, err, test.errMsg)
 			} else {
 				require.NoError(t, err)
-				require.NotEmpty(t, s.spec.Streams)
-				require.NotEmpty(t, s.spec.Connectors)
-				require.Equal(t, s.spec.Streams[0].FromUUID, asr.StreamName)
-				require.Equal(t, s.spec.Streams[0].ToUUID, dst.Id)
+				require.NotEmpty(t, testSpec(s).Streams)
+				require.NotEmpty(t, testSpec(s).Connectors)
+				require.Equal(t, testSpec(s).Streams[0].FromUUID, asr.StreamName)
+				require.Equal(t, testSpec(s).Streams[0].ToUUID, dst.Id)
 			}
 		})
 	}
@@ -326,10 +339,10 @@ func TestProcessRecords(t *testing.T) {
 	require.NoError(t, err)
 
 	require.NotEmpty(t, res)
-	require.NotEmpty(t, s.spec.Functions)
-	require.Equal(t, s.spec.Streams[0].FromUUID, asr.StreamName)
-	require.Equal(t, s.spec.Functions[0].Name, want.Functions[0].Name)
-	require.Equal(t, s.spec.Streams[0].ToUUID, res.StreamRecords.StreamName)
+	require.NotEmpty(t, testSpec(s).Functions)
+	require.Equal(t, testSpec(s).Streams[0].FromUUID, asr.StreamName)
+	require.Equal(t, testSpec(s).Functions[0].Name, want.Functions[0].Name)
+	require.Equal(t, testSpec(s).Streams[0].ToUUID, res.StreamRecords.StreamName)
 }
 
 func TestGetSpec(t *testing.T) {
@@ -344,8 +357,8 @@ func TestGetSpec(t *testing.T) {
 		{
 			description: "get spec with no function",
 			populateService: func(s *SpecBuilderService) *SpecBuilderService {
-				s.spec = exampleDeploymentSpec()
-				s.spec.Streams = append(s.spec.Streams, ir.StreamSpec{
+				*testSpec(s) = *exampleDeploymentSpec()
+				testSpec(s).Streams = append(testSpec(s).Streams, ir.StreamSpec{
 					UUID:     "1_3",
 					FromUUID: "1",
 					ToUUID:   "3",
@@ -371,8 +384,8 @@ func TestGetSpec(t *testing.T) {
 		{
 			description: "get spec with no function, set image",
 			populateService: func(s *SpecBuilderService) *SpecBuilderService {
-				s.spec = exampleDeploymentSpec()
-				s.spec.Streams = append(s.spec.Streams, ir.StreamSpec{
+				*testSpec(s) = *exampleDeploymentSpec()
+				testSpec(s).Streams = append(testSpec(s).Streams, ir.StreamSpec{
 					UUID:     "1_3",
 					FromUUID: "1",
 					ToUUID:   "3",
@@ -388,19 +401,19 @@ func TestGetSpec(t *testing.T) {
 		{
 			description: "get spec with function",
 			populateService: func(s *SpecBuilderService) *SpecBuilderService {
-				s.spec = exampleDeploymentSpec()
-				s.spec.Functions = append(s.spec.Functions, ir.FunctionSpec{
+				*testSpec(s) = *exampleDeploymentSpec()
+				testSpec(s).Functions = append(testSpec(s).Functions, ir.FunctionSpec{
 					UUID:  "2",
 					Name:  "function",
 					Image: "some/image",
 				})
-				s.spec.Streams = append(s.spec.Streams, ir.StreamSpec{
+				testSpec(s).Streams = append(testSpec(s).Streams, ir.StreamSpec{
 					UUID:     "1_2",
 					FromUUID: "1",
 					ToUUID:   "2",
 					Name:     "1_2",
 				})
-				s.spec.Streams = append(s.spec.Streams, ir.StreamSpec{
+				testSpec(s).Streams = append(testSpec(s).Streams, ir.StreamSpec{
 					UUID:     "2_3",
 					FromUUID: "2",
 					ToUUID:   "3",
@@ -440,19 +453,19 @@ func TestGetSpec(t *testing.T) {
 		{
 			description: "get spec with function, overwrite image",
 			populateService: func(s *SpecBuilderService) *SpecBuilderService {
-				s.spec = exampleDeploymentSpec()
-				s.spec.Functions = append(s.spec.Functions, ir.FunctionSpec{
+				*testSpec(s) = *exampleDeploymentSpec()
+				testSpec(s).Functions = append(testSpec(s).Functions, ir.FunctionSpec{
 					UUID:  "2",
 					Name:  "function",
 					Image: "some/image",
 				})
-				s.spec.Streams = append(s.spec.Streams, ir.StreamSpec{
+				testSpec(s).Streams = append(testSpec(s).Streams, ir.StreamSpec{
 					UUID:     "1_2",
 					FromUUID: "1",
 					ToUUID:   "2",
 					Name:     "1_2",
 				})
-				s.spec.Streams = append(s.spec.Streams, ir.StreamSpec{
+				testSpec(s).Streams = append(testSpec(s).Streams, ir.StreamSpec{
 					UUID:     "2_3",
 					FromUUID: "2",
 					ToUUID:   "3",
@@ -510,6 +523,514 @@ func TestGetSpec(t *testing.T) {
 	}
 }
 
+func TestGetSpec_DeterministicIDs(t *testing.T) {
+	ctx := context.Background()
+
+	record := func(appName string, deterministic bool) []byte {
+		s := NewSpecBuilderService()
+		_, err := s.Init(ctx, &turbinev2.InitRequest{
+			AppName:          appName,
+			ConfigFilePath:   "path/to/app",
+			Language:         turbinev2.Language_GOLANG,
+			GitSHA:           "gitsha",
+			TurbineVersion:   "0.1.0",
+			DeterministicIDs: deterministic,
+		})
+		require.NoError(t, err)
+
+		src, err := s.AddSource(ctx, &turbinev2.AddSourceRequest{
+			Name:   "source",
+			Plugin: &turbinev2.Plugin{Name: "postgres", Config: map[string]string{"table": "users"}},
+		})
+		require.NoError(t, err)
+
+		processed, err := s.ProcessRecords(ctx, &turbinev2.ProcessRecordsRequest{
+			Process:       &turbinev2.ProcessRecordsRequest_Process{Name: "anonymize"},
+			StreamRecords: &turbinev2.StreamRecords{StreamName: src.StreamName},
+		})
+		require.NoError(t, err)
+
+		for _, name := range []string{"dest1", "dest2"} {
+			dst, err := s.AddDestination(ctx, &turbinev2.AddDestinationRequest{
+				Name:   name,
+				Plugin: &turbinev2.Plugin{Name: "s3", Config: map[string]string{"bucket": name}},
+			})
+			require.NoError(t, err)
+
+			_, err = s.WriteRecords(ctx, &turbinev2.WriteRecordsRequest{
+				DestinationID: dst.Id,
+				StreamRecords: processed.StreamRecords,
+			})
+			require.NoError(t, err)
+		}
+
+		res, err := s.GetSpec(ctx, &turbinev2.GetSpecRequest{Image: "some/image"})
+		require.NoError(t, err)
+		return res.Spec
+	}
+
+	t.Run("identical apps produce identical specs", func(t *testing.T) {
+		first := record("app", true)
+		require.Equal(t, string(first), string(record("app", true)))
+
+		spec, err := ir.Unmarshal(first)
+		require.NoError(t, err)
+		ids := map[string]bool{}
+		for _, c := range spec.Connectors {
+			ids[c.UUID] = true
+		}
+		for _, f := range spec.Functions {
+			ids[f.UUID] = true
+		}
+		for _, st := range spec.Streams {
+			ids[st.UUID] = true
+		}
+		require.Len(t, ids, 3+1+3)
+	})
+
+	t.Run("IDs depend on the app name", func(t *testing.T) {
+		require.NotEqual(t, string(record("app", true)), string(record("other-app", true)))
+	})
+
+	t.Run("IDs are random by default", func(t *testing.T) {
+		require.NotEqual(t, string(record("app", false)), string(record("app", false)))
+	})
+
+	t.Run("same function applied twice to a stream", func(t *testing.T) {
+		s := NewSpecBuilderService()
+		_, err := s.Init(ctx, &turbinev2.InitRequest{
+			AppName:          "app",
+			ConfigFilePath:   "path/to/app",
+			Language:         turbinev2.Language_GOLANG,
+			DeterministicIDs: true,
+		})
+		require.NoError(t, err)
+
+		src, err := s.AddSource(ctx, &turbinev2.AddSourceRequest{
+			Name:   "source",
+			Plugin: &turbinev2.Plugin{Name: "postgres", Config: map[string]string{"url": "postgres://localhost/app", "tables": "users"}},
+		})
+		require.NoError(t, err)
+
+		var ids []string
+		for i := 0; i < 2; i++ {
+			processed, err := s.ProcessRecords(ctx, &turbinev2.ProcessRecordsRequest{
+				Process:       &turbinev2.ProcessRecordsRequest_Process{Name: "anonymize"},
+				StreamRecords: &turbinev2.StreamRecords{StreamName: src.StreamName},
+			})
+			require.NoError(t, err)
+			ids = append(ids, processed.StreamRecords.StreamName)
+		}
+		require.NotEqual(t, ids[0], ids[1])
+	})
+}
+
+func TestRegisterSecret(t *testing.T) {
+	ctx := context.Background()
+	s := NewSpecBuilderService()
+	_, err := s.Init(ctx, &turbinev2.InitRequest{
+		AppName:        "app",
+		ConfigFilePath: "path/to/app",
+		Language:       turbinev2.Language_GOLANG,
+	})
+	require.NoError(t, err)
+
+	src, err := s.AddSource(ctx, &turbinev2.AddSourceRequest{
+		Name: "source",
+		Plugin: &turbinev2.Plugin{Name: "postgres", Config: map[string]string{
+			"url": "postgres://app:${secret:PG_PASSWORD}@localhost/app",
+		}},
+	})
+	require.NoError(t, err)
+	dst, err := s.AddDestination(ctx, &turbinev2.AddDestinationRequest{
+		Name:   "destination",
+		Plugin: &turbinev2.Plugin{Name: "s3"},
+	})
+	require.NoError(t, err)
+	_, err = s.WriteRecords(ctx, &turbinev2.WriteRecordsRequest{
+		DestinationID: dst.Id,
+		StreamRecords: &turbinev2.StreamRecords{StreamName: src.StreamName},
+	})
+	require.NoError(t, err)
+
+	_, err = s.GetSpec(ctx, &turbinev2.GetSpecRequest{})
+	require.EqualError(t, err, `config "url" of connector "source" references undeclared secret "PG_PASSWORD"`)
+
+	_, err = s.RegisterSecret(ctx, &turbinev2.RegisterSecretRequest{Name: "pg-password"})
+	require.ErrorContains(t, err, "invalid RegisterSecretRequest.Name")
+
+	_, err = s.RegisterSecret(ctx, &turbinev2.RegisterSecretRequest{Name: "PG_PASSWORD", Description: "database password"})
+	require.NoError(t, err)
+
+	res, err := s.GetSpec(ctx, &turbinev2.GetSpecRequest{})
+	require.NoError(t, err)
+	spec, err := ir.Unmarshal(res.Spec)
+	require.NoError(t, err)
+	require.Equal(t, map[string]ir.SecretSpec{"PG_PASSWORD": {Description: "database password"}}, spec.Secrets)
+	require.Equal(t, "postgres://app:${secret:PG_PASSWORD}@localhost/app", spec.Connectors[0].PluginConfig["url"])
+}
+
+func TestGetSpec_Profiles(t *testing.T) {
+	ctx := context.Background()
+	appPath := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(appPath, "app.json"), []byte(`{
+		"name": "app",
+		"language": "golang",
+		"profiles": {
+			"dev": {"ENV": "dev"},
+			"prod": {"ENV": "prod", "BUCKET": "archive"}
+		}
+	}`), 0o644))
+
+	s := NewSpecBuilderService()
+	_, err := s.Init(ctx, &turbinev2.InitRequest{
+		AppName:        "app",
+		ConfigFilePath: appPath,
+		Language:       turbinev2.Language_GOLANG,
+	})
+	require.NoError(t, err)
+
+	src, err := s.AddSource(ctx, &turbinev2.AddSourceRequest{
+		Name:   "source",
+		Plugin: &turbinev2.Plugin{Name: "postgres", Config: map[string]string{"table": "orders_${ENV}"}},
+	})
+	require.NoError(t, err)
+	dst, err := s.AddDestination(ctx, &turbinev2.AddDestinationRequest{
+		Name:   "destination",
+		Plugin: &turbinev2.Plugin{Name: "s3", Config: map[string]string{"bucket": "${BUCKET}", "region": "${REGION:-eu-west-1}"}},
+	})
+	require.NoError(t, err)
+	_, err = s.WriteRecords(ctx, &turbinev2.WriteRecordsRequest{
+		DestinationID: dst.Id,
+		StreamRecords: &turbinev2.StreamRecords{StreamName: src.StreamName},
+	})
+	require.NoError(t, err)
+
+	configs := func(res *turbinev2.GetSpecResponse) []map[string]string {
+		spec, err := ir.Unmarshal(res.Spec)
+		require.NoError(t, err)
+		return []map[string]string{spec.Connectors[0].PluginConfig, spec.Connectors[1].PluginConfig}
+	}
+
+	res, err := s.GetSpec(ctx, &turbinev2.GetSpecRequest{Profile: "prod"})
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{
+		{"table": "orders_prod"},
+		{"bucket": "archive", "region": "eu-west-1"},
+	}, configs(res))
+
+	// Without a profile the placeholders are left for the platform.
+	res, err = s.GetSpec(ctx, &turbinev2.GetSpecRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []map[string]string{
+		{"table": "orders_${ENV}"},
+		{"bucket": "${BUCKET}", "region": "${REGION:-eu-west-1}"},
+	}, configs(res))
+
+	_, err = s.GetSpec(ctx, &turbinev2.GetSpecRequest{Profile: "dev"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.ErrorContains(t, err, `profile dev: config "bucket" of connector "destination": unresolved placeholder(s) ${BUCKET}`)
+
+	_, err = s.GetSpec(ctx, &turbinev2.GetSpecRequest{Profile: "staging"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.ErrorContains(t, err, "profile staging is not defined in app.json")
+}
+
+func TestSpecBuilderService_PluginSpecs(t *testing.T) {
+	ctx := context.Background()
+	specFile := filepath.Join(t.TempDir(), "mysql.yaml")
+	require.NoError(t, os.WriteFile(specFile, []byte(`
+name: mysql
+sourceParams:
+  url:
+    validations:
+      - type: required
+  fetchSize:
+    type: int
+`), 0o644))
+
+	s := NewSpecBuilderService()
+
+	// Configs are not validated by default.
+	_, err := s.AddSource(ctx, &turbinev2.AddSourceRequest{
+		Name:   "unchecked",
+		Plugin: &turbinev2.Plugin{Name: "postgres", Config: map[string]string{"tabel": "users"}},
+	})
+	require.NoError(t, err)
+
+	s.Plugins = connector.Builtin()
+	require.NoError(t, s.Plugins.Load(specFile))
+
+	_, err = s.AddSource(ctx, &turbinev2.AddSourceRequest{
+		Name: "my-source",
+		Plugin: &turbinev2.Plugin{
+			Name: "builtin:postgres",
+			Config: map[string]string{
+				"tabel":        "accounts",
+				"snapshotMode": "full",
+			},
+		},
+	})
+	require.EqualError(t, err, `rpc error: code = InvalidArgument desc = source "my-source": invalid postgres source config: `+
+		`unknown parameter "tabel", did you mean "table"?; required parameter "url" is not set; `+
+		`parameter "snapshotMode": "full" value must be included in the list [initial,never]`)
+
+	_, err = s.AddDestination(ctx, &turbinev2.AddDestinationRequest{
+		Name:   "my-destination",
+		Plugin: &turbinev2.Plugin{Name: "generator"},
+	})
+	require.EqualError(t, err, `rpc error: code = InvalidArgument desc = destination "my-destination": invalid generator destination config: `+
+		`plugin generator cannot be used as a destination`)
+
+	_, err = s.AddSource(ctx, &turbinev2.AddSourceRequest{
+		Name:   "source",
+		Plugin: &turbinev2.Plugin{Name: "standalone:mysql@v0.1.0", Config: map[string]string{"fetchsize": "10"}},
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	require.Equal(t, []*errdetails.BadRequest_FieldViolation{
+		{Field: "plugin.config.fetchsize", Description: `unknown parameter "fetchsize", did you mean "fetchSize"?`},
+		{Field: "plugin.config.url", Description: `required parameter "url" is not set`},
+	}, details[0].(*errdetails.BadRequest).FieldViolations)
+
+	_, err = s.AddSource(ctx, &turbinev2.AddSourceRequest{
+		Name:   "source",
+		Plugin: &turbinev2.Plugin{Name: "mysql", Config: map[string]string{"url": "${secret:MYSQL_URL}", "fetchSize": "10"}},
+	})
+	require.NoError(t, err)
+
+	// Plugins and versions without a specification are not validated.
+	_, err = s.AddDestination(ctx, &turbinev2.AddDestinationRequest{
+		Name:   "destination",
+		Plugin: &turbinev2.Plugin{Name: "snowflake", Config: map[string]string{"anything": "goes"}},
+	})
+	require.NoError(t, err)
+	_, err = s.AddDestination(ctx, &turbinev2.AddDestinationRequest{
+		Name:   "destination",
+		Plugin: &turbinev2.Plugin{Name: "builtin:postgres@1.0.0", Config: map[string]string{"collection": "accounts"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, testSpec(s).Connectors, 4)
+}
+
+func TestSpecBuilderService_PluginWarnings(t *testing.T) {
+	ctx := context.Background()
+
+	// Servers validate plugin configs against the builtin specifications.
+	svc := NewSpecBuilderServer().service.(*SpecBuilderService)
+	require.NotNil(t, svc.Plugins)
+
+	c, err := Embed(ctx, svc)
+	require.NoError(t, err)
+	defer c.Close()
+
+	// Unknown parameters are sent back as warnings, the source is added.
+	var header metadata.MD
+	_, err = c.AddSource(ctx, &turbinev2.AddSourceRequest{
+		Name: "my-source",
+		Plugin: &turbinev2.Plugin{
+			Name:   "postgres",
+			Config: map[string]string{"url": "postgres://localhost/app", "tabel": "accounts"},
+		},
+	}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Equal(t, []string{`source "my-source": unknown parameter "tabel", did you mean "table"?`}, header.Get(client.WarningKey))
+
+	header = nil
+	_, err = c.AddDestination(ctx, &turbinev2.AddDestinationRequest{
+		Name:   "my-destination",
+		Plugin: &turbinev2.Plugin{Name: "postgres", Config: map[string]string{"url": "postgres://localhost/app"}},
+	}, grpc.Header(&header))
+	require.NoError(t, err)
+	require.Empty(t, header.Get(client.WarningKey))
+	require.Len(t, testSpec(svc).Connectors, 2)
+}
+
+func TestSpecBuilderService_Sessions(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := EmbedSpecBuilderService(ctx)
+	require.NoError(t, err)
+	defer c.Close()
+
+	initReq := func(appName string) *turbinev2.InitRequest {
+		return &turbinev2.InitRequest{
+			AppName:        appName,
+			ConfigFilePath: "path/to/app",
+			Language:       turbinev2.Language_GOLANG,
+		}
+	}
+	record := func(ctx context.Context, source string) {
+		_, err := c.AddSource(ctx, &turbinev2.AddSourceRequest{
+			Name:   source,
+			Plugin: &turbinev2.Plugin{Name: "postgres"},
+		})
+		require.NoError(t, err)
+	}
+	sources := func(ctx context.Context) []string {
+		res, err := c.GetSpec(ctx, &turbinev2.GetSpecRequest{})
+		require.NoError(t, err)
+		spec, err := ir.Unmarshal(res.Spec)
+		require.NoError(t, err)
+
+		var names []string
+		for _, c := range spec.Connectors {
+			names = append(names, c.Name)
+		}
+		return names
+	}
+
+	// Interleaved recordings do not see each other.
+	ctxA, idA, err := client.InitSession(ctx, c, initReq("app-a"))
+	require.NoError(t, err)
+	ctxB, idB, err := client.InitSession(ctx, c, initReq("app-b"))
+	require.NoError(t, err)
+	require.NotEmpty(t, idA)
+	require.NotEqual(t, idA, idB)
+
+	record(ctxA, "source-a")
+	record(ctxB, "source-b")
+	require.Equal(t, []string{"source-a"}, sources(ctxA))
+	require.Equal(t, []string{"source-b"}, sources(ctxB))
+
+	// Calls without a session ID use the last default session.
+	require.Equal(t, []string{"source-b"}, sources(ctx))
+
+	// Init resets the session it is called for.
+	_, err = c.Init(ctxA, initReq("app-a"))
+	require.NoError(t, err)
+	require.Empty(t, sources(ctxA))
+	require.Equal(t, []string{"source-b"}, sources(ctxB))
+
+	// Clients may pick their own session ID.
+	ctxC := client.WithSessionID(ctx, "my-session")
+	_, err = c.Init(ctxC, initReq("app-c"))
+	require.NoError(t, err)
+
+	res, err := c.ListSessions(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	var got []string
+	for _, sess := range res.Sessions {
+		got = append(got, sess.Id+"="+sess.AppName)
+	}
+	require.ElementsMatch(t, []string{idA + "=app-a", idB + "=app-b", "my-session=app-c"}, got)
+
+	_, err = c.GetSpec(client.WithSessionID(ctx, "unknown"), &turbinev2.GetSpecRequest{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestSpecBuilderService_DefaultSessionTakeover(t *testing.T) {
+	s := NewSpecBuilderService()
+	ctx := context.Background()
+
+	initReq := func(appName string) *turbinev2.InitRequest {
+		return &turbinev2.InitRequest{
+			AppName:        appName,
+			ConfigFilePath: "path/to/app",
+			Language:       turbinev2.Language_GOLANG,
+		}
+	}
+	addSource := func(name string) {
+		_, err := s.AddSource(ctx, &turbinev2.AddSourceRequest{
+			Name:   name,
+			Plugin: &turbinev2.Plugin{Name: "postgres"},
+		})
+		require.NoError(t, err)
+	}
+
+	// Two clients unaware of sessions record at the same time.
+	_, err := s.Init(ctx, initReq("app-a"))
+	require.NoError(t, err)
+	idA := s.defaultID
+	addSource("source-a")
+
+	_, err = s.Init(ctx, initReq("app-b"))
+	require.NoError(t, err)
+	idB := s.defaultID
+	require.NotEqual(t, idA, idB)
+
+	// The calls of the first client now land in the recording of the second,
+	// which is why such clients cannot record concurrently.
+	addSource("source-a2")
+
+	require.Len(t, s.sessions[idA].spec.Connectors, 1)
+	require.Equal(t, "source-a", s.sessions[idA].spec.Connectors[0].Name)
+	require.Len(t, s.sessions[idB].spec.Connectors, 1)
+	require.Equal(t, "source-a2", s.sessions[idB].spec.Connectors[0].Name)
+	require.Equal(t, "app-b", s.sessions[idB].appName)
+}
+
+func TestSpecBuilderService_SessionExpiry(t *testing.T) {
+	var (
+		now = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		s   = NewSpecBuilderService()
+	)
+	s.SessionTTL = time.Minute
+	s.now = func() time.Time { return now }
+
+	idle := metadata.NewIncomingContext(context.Background(), metadata.Pairs(client.SessionIDKey, "idle"))
+	active := metadata.NewIncomingContext(context.Background(), metadata.Pairs(client.SessionIDKey, "active"))
+	for _, ctx := range []context.Context{idle, active} {
+		_, err := s.Init(ctx, &turbinev2.InitRequest{
+			AppName:        "app",
+			ConfigFilePath: "path/to/app",
+			Language:       turbinev2.Language_GOLANG,
+		})
+		require.NoError(t, err)
+	}
+
+	now = now.Add(45 * time.Second)
+	_, err := s.GetSpec(active, &turbinev2.GetSpecRequest{})
+	require.NoError(t, err)
+
+	now = now.Add(45 * time.Second)
+	res, err := s.ListSessions(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, res.Sessions, 1)
+	require.Equal(t, "active", res.Sessions[0].Id)
+	require.Equal(t, now.Add(-45*time.Second), res.Sessions[0].LastUsedAt.AsTime())
+
+	_, err = s.GetSpec(idle, &turbinev2.GetSpecRequest{})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	require.ErrorContains(t, err, `session "idle" not found, it may have expired`)
+}
+
+func TestSpecBuilderService_ConcurrentSessions(t *testing.T) {
+	s := NewSpecBuilderService()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := metadata.NewIncomingContext(context.Background(),
+				metadata.Pairs(client.SessionIDKey, fmt.Sprint("session-", i)))
+
+			_, err := s.Init(ctx, &turbinev2.InitRequest{
+				AppName:        fmt.Sprint("app-", i),
+				ConfigFilePath: "path/to/app",
+				Language:       turbinev2.Language_GOLANG,
+			})
+			assert.NoError(t, err)
+			for j := 0; j < 10; j++ {
+				_, err := s.AddSource(ctx, &turbinev2.AddSourceRequest{
+					Name:   fmt.Sprint("source-", j),
+					Plugin: &turbinev2.Plugin{Name: "postgres"},
+				})
+				assert.NoError(t, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	require.Len(t, s.sessions, 8)
+	for _, sess := range s.sessions {
+		require.Len(t, sess.spec.Connectors, 10)
+	}
+}
+
 func exampleDeploymentSpec() *ir.DeploymentSpec {
 	return &ir.DeploymentSpec{
 		Connectors: []ir.ConnectorSpec{
@@ -543,3 +1064,12 @@ func exampleDeploymentSpec() *ir.DeploymentSpec {
 		},
 	}
 }
+
+// testSpec returns the spec recorded by calls made without a session ID.
+func testSpec(s *SpecBuilderService) *ir.DeploymentSpec {
+	sess, err := s.session(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	return sess.spec
+}
@@ -0,0 +1,204 @@
+// Package transport holds the address and TLS settings shared by the Turbine
+// core server and its clients. Addresses are either host:port TCP addresses
+// or unix:// socket paths, e.g. unix:///tmp/turbine.sock.
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	// DefaultAddr is the address of the core server when EnvAddr is not set.
+	DefaultAddr = "localhost:50500"
+	// UnixScheme prefixes the address of a unix domain socket.
+	UnixScheme = "unix://"
+
+	// EnvAddr overrides the address of the core server.
+	EnvAddr = "TURBINE_CORE_SERVER"
+	// EnvTLSCert, EnvTLSKey and EnvTLSCA hold the paths of the PEM encoded
+	// certificate, key and certificate authority used for TLS.
+	EnvTLSCert = "TURBINE_CORE_TLS_CERT"
+	EnvTLSKey  = "TURBINE_CORE_TLS_KEY"
+	EnvTLSCA   = "TURBINE_CORE_TLS_CA"
+	// EnvTLSServerName overrides the name clients verify the server
+	// certificate against.
+	EnvTLSServerName = "TURBINE_CORE_TLS_SERVER_NAME"
+)
+
+// AddrFromEnv returns the address of the core server set in the environment,
+// or DefaultAddr.
+func AddrFromEnv() string {
+	if addr := os.Getenv(EnvAddr); addr != "" {
+		return addr
+	}
+	return DefaultAddr
+}
+
+// TLSConfig holds the paths of PEM encoded files used to secure connections.
+//
+// A server needs a certificate and key, and requires and verifies client
+// certificates against CAFile when set (mTLS). A client verifies the server
+// against CAFile, or the system roots when empty, and presents its own
+// certificate and key when set.
+type TLSConfig struct {
+	CertFile   string
+	KeyFile    string
+	CAFile     string
+	ServerName string
+}
+
+// TLSConfigFromEnv reads the TLS settings from the environment.
+func TLSConfigFromEnv() TLSConfig {
+	return TLSConfig{
+		CertFile:   os.Getenv(EnvTLSCert),
+		KeyFile:    os.Getenv(EnvTLSKey),
+		CAFile:     os.Getenv(EnvTLSCA),
+		ServerName: os.Getenv(EnvTLSServerName),
+	}
+}
+
+// Enabled reports whether any TLS setting is set.
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CAFile != ""
+}
+
+// ServerCredentials returns the credentials of a server, insecure ones when
+// TLS is not enabled.
+func (c TLSConfig) ServerCredentials() (credentials.TransportCredentials, error) {
+	if !c.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+	if c.CertFile == "" || c.KeyFile == "" {
+		return nil, fmt.Errorf("a TLS server requires a certificate and a key")
+	}
+
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if c.CAFile != "" {
+		pool, err := loadCA(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// ClientCredentials returns the credentials of a client, insecure ones when
+// TLS is not enabled.
+func (c TLSConfig) ClientCredentials() (credentials.TransportCredentials, error) {
+	if !c.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return nil, fmt.Errorf("a TLS client certificate requires both a certificate and a key")
+	}
+
+	cfg := &tls.Config{
+		ServerName: c.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		pool, err := loadCA(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if c.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+func loadCA(file string) (*x509.CertPool, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TLS certificate authority: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(b) {
+		return nil, fmt.Errorf("no certificate found in %s", file)
+	}
+	return pool, nil
+}
+
+// Listen listens on a TCP or unix:// address. A socket left behind by a
+// previous server, which refuses connections, is removed. A socket another
+// server still accepts connections on is left alone and reported as in use.
+func Listen(addr string) (net.Listener, error) {
+	path, ok := socketPath(addr)
+	if !ok {
+		return net.Listen("tcp", addr)
+	}
+
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		if err := removeStaleSocket(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return net.Listen("unix", path)
+}
+
+// removeStaleSocket removes a socket nothing accepts connections on.
+func removeStaleSocket(path string) error {
+	conn, err := net.Dial("unix", path)
+	if err == nil {
+		conn.Close()
+		return &net.OpError{Op: "listen", Net: "unix", Addr: &net.UnixAddr{Name: path, Net: "unix"}, Err: syscall.EADDRINUSE}
+	}
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// Target returns the gRPC dial target of an address. gRPC only accepts
+// absolute paths after unix://, relative ones use the unix: form.
+func Target(addr string) string {
+	path, ok := socketPath(addr)
+	if !ok {
+		return addr
+	}
+	if filepath.IsAbs(path) {
+		return UnixScheme + path
+	}
+	return "unix:" + path
+}
+
+func socketPath(addr string) (string, bool) {
+	switch {
+	case strings.HasPrefix(addr, UnixScheme):
+		return strings.TrimPrefix(addr, UnixScheme), true
+	case strings.HasPrefix(addr, "unix:"):
+		return strings.TrimPrefix(addr, "unix:"), true
+	default:
+		return "", false
+	}
+}
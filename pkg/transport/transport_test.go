@@ -0,0 +1,135 @@
+package transport
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTarget(t *testing.T) {
+	tests := []struct {
+		addr string
+		want string
+	}{
+		{addr: "localhost:50500", want: "localhost:50500"},
+		{addr: "unix:///tmp/turbine.sock", want: "unix:///tmp/turbine.sock"},
+		{addr: "unix://turbine.sock", want: "unix:turbine.sock"},
+		{addr: "unix:turbine.sock", want: "unix:turbine.sock"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.addr, func(t *testing.T) {
+			assert.Equal(t, tc.want, Target(tc.addr))
+		})
+	}
+}
+
+func TestListen_Unix(t *testing.T) {
+	dir, err := os.MkdirTemp("", "turbine")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "sockets", "core.sock")
+
+	// A socket left behind by a crashed server.
+	stale, err := net.Listen("unix", filepath.Join(dir, "stale.sock"))
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.Rename(filepath.Join(dir, "stale.sock"), path))
+
+	lis, err := Listen(UnixScheme + path)
+	require.NoError(t, err)
+	defer lis.Close()
+	assert.Equal(t, "unix", lis.Addr().Network())
+	assert.Equal(t, path, lis.Addr().String())
+}
+
+func TestListen_UnixInUse(t *testing.T) {
+	dir, err := os.MkdirTemp("", "turbine")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "core.sock")
+
+	running, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer running.Close()
+
+	_, err = Listen(UnixScheme + path)
+	require.ErrorIs(t, err, syscall.EADDRINUSE)
+	assert.ErrorContains(t, err, "address already in use")
+
+	// The socket of the running server is kept.
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestAddrFromEnv(t *testing.T) {
+	t.Setenv(EnvAddr, "")
+	assert.Equal(t, DefaultAddr, AddrFromEnv())
+
+	t.Setenv(EnvAddr, "unix:///tmp/turbine.sock")
+	assert.Equal(t, "unix:///tmp/turbine.sock", AddrFromEnv())
+}
+
+func TestTLSConfig_Credentials(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty.pem"), nil, 0o644))
+
+	tests := []struct {
+		desc      string
+		cfg       TLSConfig
+		wantProto string
+		serverErr string
+		clientErr string
+	}{
+		{
+			desc:      "insecure without settings",
+			wantProto: "insecure",
+		},
+		{
+			desc:      "server requires a key",
+			cfg:       TLSConfig{CertFile: "cert.pem"},
+			serverErr: "a TLS server requires a certificate and a key",
+			clientErr: "a TLS client certificate requires both a certificate and a key",
+		},
+		{
+			desc:      "certificate authority without certificates",
+			cfg:       TLSConfig{CAFile: filepath.Join(dir, "empty.pem")},
+			serverErr: "a TLS server requires a certificate and a key",
+			clientErr: "no certificate found in " + filepath.Join(dir, "empty.pem"),
+		},
+		{
+			desc:      "missing files",
+			cfg:       TLSConfig{CertFile: filepath.Join(dir, "cert.pem"), KeyFile: filepath.Join(dir, "key.pem")},
+			serverErr: "failed to load TLS certificate",
+			clientErr: "failed to load TLS client certificate",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			server, err := tc.cfg.ServerCredentials()
+			if tc.serverErr != "" {
+				require.ErrorContains(t, err, tc.serverErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantProto, server.Info().SecurityProtocol)
+			}
+
+			client, err := tc.cfg.ClientCredentials()
+			if tc.clientErr != "" {
+				require.ErrorContains(t, err, tc.clientErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tc.wantProto, client.Info().SecurityProtocol)
+			}
+		})
+	}
+}
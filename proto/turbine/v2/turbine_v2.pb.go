@@ -12,7 +12,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
-	_ "google.golang.org/protobuf/types/known/timestamppb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -86,6 +86,9 @@ type InitRequest struct {
 	Language       Language `protobuf:"varint,3,opt,name=language,proto3,enum=turbine.v2.Language" json:"language,omitempty"`
 	GitSHA         string   `protobuf:"bytes,4,opt,name=gitSHA,proto3" json:"gitSHA,omitempty"`
 	TurbineVersion string   `protobuf:"bytes,5,opt,name=turbineVersion,proto3" json:"turbineVersion,omitempty"`
+	// Derive resource IDs from the app name, the resource name and its position
+	// in the graph, so that recording the same app twice yields the same spec.
+	DeterministicIDs bool `protobuf:"varint,6,opt,name=deterministicIDs,proto3" json:"deterministicIDs,omitempty"`
 }
 
 func (x *InitRequest) Reset() {
@@ -155,6 +158,13 @@ func (x *InitRequest) GetTurbineVersion() string {
 	return ""
 }
 
+func (x *InitRequest) GetDeterministicIDs() bool {
+	if x != nil {
+		return x.DeterministicIDs
+	}
+	return false
+}
+
 type AddSourceRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -312,6 +322,62 @@ func (x *ReadRecordsRequest) GetSourceStream() string {
 	return ""
 }
 
+type ReadRecordsStreamRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SourceStream string `protobuf:"bytes,1,opt,name=sourceStream,proto3" json:"sourceStream,omitempty"`
+	// Maximum number of records sent in a single response, the server default is used when 0.
+	BatchSize uint32 `protobuf:"varint,2,opt,name=batchSize,proto3" json:"batchSize,omitempty"`
+}
+
+func (x *ReadRecordsStreamRequest) Reset() {
+	*x = ReadRecordsStreamRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReadRecordsStreamRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadRecordsStreamRequest) ProtoMessage() {}
+
+func (x *ReadRecordsStreamRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadRecordsStreamRequest.ProtoReflect.Descriptor instead.
+func (*ReadRecordsStreamRequest) Descriptor() ([]byte, []int) {
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReadRecordsStreamRequest) GetSourceStream() string {
+	if x != nil {
+		return x.SourceStream
+	}
+	return ""
+}
+
+func (x *ReadRecordsStreamRequest) GetBatchSize() uint32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
 type ReadRecordsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -323,7 +389,7 @@ type ReadRecordsResponse struct {
 func (x *ReadRecordsResponse) Reset() {
 	*x = ReadRecordsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[4]
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -336,7 +402,7 @@ func (x *ReadRecordsResponse) String() string {
 func (*ReadRecordsResponse) ProtoMessage() {}
 
 func (x *ReadRecordsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[4]
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,7 +415,7 @@ func (x *ReadRecordsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadRecordsResponse.ProtoReflect.Descriptor instead.
 func (*ReadRecordsResponse) Descriptor() ([]byte, []int) {
-	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{4}
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ReadRecordsResponse) GetStreamRecords() *StreamRecords {
@@ -371,7 +437,7 @@ type ProcessRecordsRequest struct {
 func (x *ProcessRecordsRequest) Reset() {
 	*x = ProcessRecordsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[5]
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -384,7 +450,7 @@ func (x *ProcessRecordsRequest) String() string {
 func (*ProcessRecordsRequest) ProtoMessage() {}
 
 func (x *ProcessRecordsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[5]
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -397,7 +463,7 @@ func (x *ProcessRecordsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessRecordsRequest.ProtoReflect.Descriptor instead.
 func (*ProcessRecordsRequest) Descriptor() ([]byte, []int) {
-	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{5}
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ProcessRecordsRequest) GetProcess() *ProcessRecordsRequest_Process {
@@ -425,7 +491,7 @@ type ProcessRecordsResponse struct {
 func (x *ProcessRecordsResponse) Reset() {
 	*x = ProcessRecordsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[6]
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -438,7 +504,7 @@ func (x *ProcessRecordsResponse) String() string {
 func (*ProcessRecordsResponse) ProtoMessage() {}
 
 func (x *ProcessRecordsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[6]
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -451,7 +517,7 @@ func (x *ProcessRecordsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessRecordsResponse.ProtoReflect.Descriptor instead.
 func (*ProcessRecordsResponse) Descriptor() ([]byte, []int) {
-	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{6}
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ProcessRecordsResponse) GetStreamRecords() *StreamRecords {
@@ -473,7 +539,7 @@ type AddDestinationRequest struct {
 func (x *AddDestinationRequest) Reset() {
 	*x = AddDestinationRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[7]
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -486,7 +552,7 @@ func (x *AddDestinationRequest) String() string {
 func (*AddDestinationRequest) ProtoMessage() {}
 
 func (x *AddDestinationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[7]
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -499,7 +565,7 @@ func (x *AddDestinationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddDestinationRequest.ProtoReflect.Descriptor instead.
 func (*AddDestinationRequest) Descriptor() ([]byte, []int) {
-	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{7}
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AddDestinationRequest) GetName() string {
@@ -527,7 +593,7 @@ type AddDestinationResponse struct {
 func (x *AddDestinationResponse) Reset() {
 	*x = AddDestinationResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[8]
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -540,7 +606,7 @@ func (x *AddDestinationResponse) String() string {
 func (*AddDestinationResponse) ProtoMessage() {}
 
 func (x *AddDestinationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[8]
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -553,7 +619,7 @@ func (x *AddDestinationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddDestinationResponse.ProtoReflect.Descriptor instead.
 func (*AddDestinationResponse) Descriptor() ([]byte, []int) {
-	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{8}
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AddDestinationResponse) GetId() string {
@@ -575,7 +641,7 @@ type WriteRecordsRequest struct {
 func (x *WriteRecordsRequest) Reset() {
 	*x = WriteRecordsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[9]
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -588,7 +654,7 @@ func (x *WriteRecordsRequest) String() string {
 func (*WriteRecordsRequest) ProtoMessage() {}
 
 func (x *WriteRecordsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[9]
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -601,7 +667,7 @@ func (x *WriteRecordsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteRecordsRequest.ProtoReflect.Descriptor instead.
 func (*WriteRecordsRequest) Descriptor() ([]byte, []int) {
-	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{9}
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *WriteRecordsRequest) GetDestinationID() string {
@@ -618,18 +684,76 @@ func (x *WriteRecordsRequest) GetStreamRecords() *StreamRecords {
 	return nil
 }
 
+type RegisterSecretRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *RegisterSecretRequest) Reset() {
+	*x = RegisterSecretRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterSecretRequest) ProtoMessage() {}
+
+func (x *RegisterSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterSecretRequest.ProtoReflect.Descriptor instead.
+func (*RegisterSecretRequest) Descriptor() ([]byte, []int) {
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RegisterSecretRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterSecretRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
 type GetSpecRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Image string `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	// Resolves the ${NAME} placeholders of plugin configs with the variables of
+	// this profile of app.json, placeholders are left as is when empty.
+	Profile string `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
 }
 
 func (x *GetSpecRequest) Reset() {
 	*x = GetSpecRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[10]
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -642,7 +766,7 @@ func (x *GetSpecRequest) String() string {
 func (*GetSpecRequest) ProtoMessage() {}
 
 func (x *GetSpecRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[10]
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -655,7 +779,7 @@ func (x *GetSpecRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSpecRequest.ProtoReflect.Descriptor instead.
 func (*GetSpecRequest) Descriptor() ([]byte, []int) {
-	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{10}
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetSpecRequest) GetImage() string {
@@ -665,6 +789,13 @@ func (x *GetSpecRequest) GetImage() string {
 	return ""
 }
 
+func (x *GetSpecRequest) GetProfile() string {
+	if x != nil {
+		return x.Profile
+	}
+	return ""
+}
+
 type GetSpecResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -676,7 +807,7 @@ type GetSpecResponse struct {
 func (x *GetSpecResponse) Reset() {
 	*x = GetSpecResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[11]
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -689,7 +820,7 @@ func (x *GetSpecResponse) String() string {
 func (*GetSpecResponse) ProtoMessage() {}
 
 func (x *GetSpecResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[11]
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -702,7 +833,7 @@ func (x *GetSpecResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSpecResponse.ProtoReflect.Descriptor instead.
 func (*GetSpecResponse) Descriptor() ([]byte, []int) {
-	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{11}
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetSpecResponse) GetSpec() []byte {
@@ -712,6 +843,124 @@ func (x *GetSpecResponse) GetSpec() []byte {
 	return nil
 }
 
+type Session struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AppName    string                 `protobuf:"bytes,2,opt,name=appName,proto3" json:"appName,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	LastUsedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=lastUsedAt,proto3" json:"lastUsedAt,omitempty"`
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Session) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Session) GetAppName() string {
+	if x != nil {
+		return x.AppName
+	}
+	return ""
+}
+
+func (x *Session) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Session) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions []*Session `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
 // Represents a collection of records consumed from a stream.
 type StreamRecords struct {
 	state         protoimpl.MessageState
@@ -725,7 +974,7 @@ type StreamRecords struct {
 func (x *StreamRecords) Reset() {
 	*x = StreamRecords{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[12]
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -738,7 +987,7 @@ func (x *StreamRecords) String() string {
 func (*StreamRecords) ProtoMessage() {}
 
 func (x *StreamRecords) ProtoReflect() protoreflect.Message {
-	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[12]
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -751,7 +1000,7 @@ func (x *StreamRecords) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamRecords.ProtoReflect.Descriptor instead.
 func (*StreamRecords) Descriptor() ([]byte, []int) {
-	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{12}
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *StreamRecords) GetStreamName() string {
@@ -780,7 +1029,7 @@ type Plugin struct {
 func (x *Plugin) Reset() {
 	*x = Plugin{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[13]
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -793,7 +1042,7 @@ func (x *Plugin) String() string {
 func (*Plugin) ProtoMessage() {}
 
 func (x *Plugin) ProtoReflect() protoreflect.Message {
-	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[13]
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -806,7 +1055,7 @@ func (x *Plugin) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Plugin.ProtoReflect.Descriptor instead.
 func (*Plugin) Descriptor() ([]byte, []int) {
-	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{13}
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *Plugin) GetName() string {
@@ -834,7 +1083,7 @@ type ProcessRecordsRequest_Process struct {
 func (x *ProcessRecordsRequest_Process) Reset() {
 	*x = ProcessRecordsRequest_Process{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[14]
+		mi := &file_turbine_v2_turbine_v2_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -847,7 +1096,7 @@ func (x *ProcessRecordsRequest_Process) String() string {
 func (*ProcessRecordsRequest_Process) ProtoMessage() {}
 
 func (x *ProcessRecordsRequest_Process) ProtoReflect() protoreflect.Message {
-	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[14]
+	mi := &file_turbine_v2_turbine_v2_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,7 +1109,7 @@ func (x *ProcessRecordsRequest_Process) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessRecordsRequest_Process.ProtoReflect.Descriptor instead.
 func (*ProcessRecordsRequest_Process) Descriptor() ([]byte, []int) {
-	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{5, 0}
+	return file_turbine_v2_turbine_v2_proto_rawDescGZIP(), []int{6, 0}
 }
 
 func (x *ProcessRecordsRequest_Process) GetName() string {
@@ -882,7 +1131,7 @@ var file_turbine_v2_turbine_v2_proto_rawDesc = []byte{
 	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x18, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x64, 0x63,
 	0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x64, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69,
-	0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdd, 0x01, 0x0a, 0x0b, 0x49,
+	0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x89, 0x02, 0x0a, 0x0b, 0x49,
 	0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x07, 0x61, 0x70,
 	0x70, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04,
 	0x72, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61, 0x70, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a,
@@ -896,106 +1145,145 @@ var file_turbine_v2_turbine_v2_proto_rawDesc = []byte{
 	0x74, 0x53, 0x48, 0x41, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x67, 0x69, 0x74, 0x53,
 	0x48, 0x41, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x56, 0x65, 0x72,
 	0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x75, 0x72, 0x62,
-	0x69, 0x6e, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5b, 0x0a, 0x10, 0x41, 0x64,
-	0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b,
-	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42,
-	0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x70,
-	0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x75,
-	0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x52,
-	0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x22, 0x55, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10,
-	0x01, 0x52, 0x02, 0x69, 0x64, 0x12, 0x27, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02,
-	0x10, 0x01, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x41,
-	0x0a, 0x12, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72,
-	0x02, 0x10, 0x01, 0x52, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61,
-	0x6d, 0x22, 0x60, 0x0a, 0x13, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65,
-	0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a,
-	0x01, 0x02, 0x10, 0x01, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f,
-	0x72, 0x64, 0x73, 0x22, 0xd9, 0x01, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52,
-	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x4d, 0x0a,
-	0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29,
-	0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01,
-	0x02, 0x10, 0x01, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x49, 0x0a, 0x0d,
-	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x69, 0x6e, 0x65, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x10, 0x64, 0x65,
+	0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x69, 0x63, 0x49, 0x44, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x10, 0x64, 0x65, 0x74, 0x65, 0x72, 0x6d, 0x69, 0x6e, 0x69, 0x73,
+	0x74, 0x69, 0x63, 0x49, 0x44, 0x73, 0x22, 0x5b, 0x0a, 0x10, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10,
+	0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e,
+	0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x52, 0x06, 0x70, 0x6c, 0x75,
+	0x67, 0x69, 0x6e, 0x22, 0x55, 0x0a, 0x11, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x27, 0x0a, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x0a,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x41, 0x0a, 0x12, 0x52, 0x65,
+	0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x2b, 0x0a, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52,
+	0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x22, 0x65, 0x0a,
+	0x18, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2b, 0x0a, 0x0c, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x1c, 0x0a, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68, 0x53,
+	0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x53, 0x69, 0x7a, 0x65, 0x22, 0x60, 0x0a, 0x13, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f,
+	0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0d, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x08, 0xfa,
+	0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52,
+	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0xd9, 0x01, 0x0a, 0x15, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x4d, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x29, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x42, 0x08, 0xfa, 0x42,
+	0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12,
+	0x49, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65,
+	0x2e, 0x76, 0x32, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0d, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x1a, 0x26, 0x0a, 0x07, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x22, 0x63, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0d,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32,
 	0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x08,
 	0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x1a, 0x26, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x63, 0x65,
-	0x73, 0x73, 0x12, 0x1b, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22,
-	0x63, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0d, 0x73, 0x74, 0x72,
-	0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x19, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05,
-	0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63,
-	0x6f, 0x72, 0x64, 0x73, 0x22, 0x60, 0x0a, 0x15, 0x41, 0x64, 0x64, 0x44, 0x65, 0x73, 0x74, 0x69,
-	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04,
-	0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x70, 0x6c,
-	0x75, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x74, 0x75, 0x72,
-	0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x52, 0x06,
-	0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x22, 0x31, 0x0a, 0x16, 0x41, 0x64, 0x64, 0x44, 0x65, 0x73,
-	0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x17, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42,
-	0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x02, 0x69, 0x64, 0x22, 0x8f, 0x01, 0x0a, 0x13, 0x57, 0x72,
-	0x69, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x2d, 0x0a, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10,
-	0x01, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44,
-	0x12, 0x49, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
-	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e,
-	0x65, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x0d, 0x73, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x26, 0x0a, 0x0e, 0x47,
-	0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a,
-	0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x69, 0x6d,
-	0x61, 0x67, 0x65, 0x22, 0x25, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0x66, 0x0a, 0x0d, 0x53, 0x74,
-	0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x0a, 0x73,
-	0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
-	0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d,
-	0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x18,
-	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x64, 0x63, 0x2e,
-	0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x73, 0x22, 0x98, 0x01, 0x0a, 0x06, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12, 0x1b, 0x0a,
-	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04,
-	0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x63, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74, 0x75, 0x72,
-	0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x2e, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66,
-	0x69, 0x67, 0x1a, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x3c, 0x0a,
-	0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x47, 0x4f, 0x4c,
-	0x41, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x59, 0x54, 0x48, 0x4f, 0x4e, 0x10,
-	0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x4a, 0x41, 0x56, 0x41, 0x53, 0x43, 0x52, 0x49, 0x50, 0x54, 0x10,
-	0x02, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x55, 0x42, 0x59, 0x10, 0x03, 0x32, 0x9b, 0x04, 0x0a, 0x07,
-	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x04, 0x49, 0x6e, 0x69, 0x74, 0x12,
-	0x17, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x49, 0x6e, 0x69,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x12, 0x48, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1c, 0x2e,
+	0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x60, 0x0a, 0x15, 0x41, 0x64, 0x64, 0x44, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07,
+	0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2a, 0x0a,
+	0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
+	0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69,
+	0x6e, 0x52, 0x06, 0x70, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x22, 0x31, 0x0a, 0x16, 0x41, 0x64, 0x64,
+	0x44, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x02, 0x69, 0x64, 0x22, 0x8f, 0x01, 0x0a,
+	0x13, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa, 0x42, 0x04,
+	0x72, 0x02, 0x10, 0x01, 0x52, 0x0d, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x44, 0x12, 0x49, 0x0a, 0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x75, 0x72,
+	0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x73, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52,
+	0x0d, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x6e,
+	0x0a, 0x15, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x33, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1f, 0xfa, 0x42, 0x1c, 0x72, 0x1a, 0x32, 0x18, 0x5e, 0x5b,
+	0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x5f, 0x5d, 0x5b, 0x41, 0x2d, 0x5a, 0x61, 0x2d, 0x7a, 0x30,
+	0x2d, 0x39, 0x5f, 0x5d, 0x2a, 0x24, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x40,
+	0x0a, 0x0e, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x22, 0x25, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x22, 0xa9, 0x01, 0x0a, 0x07, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x70, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x38, 0x0a,
+	0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x3a, 0x0a, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55,
+	0x73, 0x65, 0x64, 0x41, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x73, 0x65,
+	0x64, 0x41, 0x74, 0x22, 0x47, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x66, 0x0a, 0x0d,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x27, 0x0a,
+	0x0a, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x0a, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x6f, 0x70, 0x65, 0x6e, 0x63, 0x64,
+	0x63, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x07, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x22, 0x98, 0x01, 0x0a, 0x06, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e, 0x12,
+	0x1b, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x07, 0xfa,
+	0x42, 0x04, 0x72, 0x02, 0x10, 0x01, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x36, 0x0a, 0x06,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x74,
+	0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x6c, 0x75, 0x67, 0x69, 0x6e,
+	0x2e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x63, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x1a, 0x39, 0x0a, 0x0b, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a,
+	0x3c, 0x0a, 0x08, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x0a, 0x0a, 0x06, 0x47,
+	0x4f, 0x4c, 0x41, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x59, 0x54, 0x48, 0x4f,
+	0x4e, 0x10, 0x01, 0x12, 0x0e, 0x0a, 0x0a, 0x4a, 0x41, 0x56, 0x41, 0x53, 0x43, 0x52, 0x49, 0x50,
+	0x54, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x55, 0x42, 0x59, 0x10, 0x03, 0x32, 0x90, 0x06,
+	0x0a, 0x07, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x37, 0x0a, 0x04, 0x49, 0x6e, 0x69,
+	0x74, 0x12, 0x17, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x49,
+	0x6e, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x48, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12,
+	0x1c, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x41, 0x64, 0x64,
+	0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
 	0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x41, 0x64, 0x64, 0x53, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x75,
-	0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x41, 0x64, 0x64, 0x53, 0x6f, 0x75, 0x72,
-	0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0b, 0x52, 0x65,
-	0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1e, 0x2e, 0x74, 0x75, 0x72, 0x62,
-	0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x75, 0x72, 0x62,
-	0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72,
-	0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x57, 0x0a, 0x0e, 0x50, 0x72,
+	0x75, 0x72, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0b,
+	0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x1e, 0x2e, 0x74, 0x75,
+	0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x75,
+	0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x11,
+	0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x12, 0x24, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x52,
+	0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e,
+	0x65, 0x2e, 0x76, 0x32, 0x2e, 0x52, 0x65, 0x61, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x57, 0x0a, 0x0e, 0x50, 0x72,
 	0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x12, 0x21, 0x2e, 0x74,
 	0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73,
 	0x73, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
@@ -1011,22 +1299,31 @@ var file_turbine_v2_turbine_v2_proto_rawDesc = []byte{
 	0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x52,
 	0x65, 0x63, 0x6f, 0x72, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
 	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x42, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63,
-	0x12, 0x1a, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65,
-	0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74,
-	0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65,
-	0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x9f, 0x01, 0x0a, 0x0e, 0x63, 0x6f,
-	0x6d, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x42, 0x0e, 0x54, 0x75,
-	0x72, 0x62, 0x69, 0x6e, 0x65, 0x56, 0x32, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x48, 0x02, 0x50, 0x01,
-	0x5a, 0x32, 0x62, 0x75, 0x66, 0x2e, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x2f, 0x6d, 0x65, 0x72, 0x6f,
-	0x78, 0x61, 0x2f, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2f,
-	0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2f, 0x76, 0x32, 0x3b, 0x74, 0x75, 0x72, 0x62, 0x69,
-	0x6e, 0x65, 0x76, 0x32, 0xa2, 0x02, 0x03, 0x54, 0x58, 0x58, 0xaa, 0x02, 0x0a, 0x54, 0x75, 0x72,
-	0x62, 0x69, 0x6e, 0x65, 0x2e, 0x56, 0x32, 0xca, 0x02, 0x0a, 0x54, 0x75, 0x72, 0x62, 0x69, 0x6e,
-	0x65, 0x5c, 0x56, 0x32, 0xe2, 0x02, 0x16, 0x54, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x5c, 0x56,
-	0x32, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0b,
-	0x54, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x3a, 0x3a, 0x56, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x4b, 0x0a, 0x0e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x21, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e,
+	0x65, 0x2e, 0x76, 0x32, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x42, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x12, 0x1a, 0x2e,
+	0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x70,
+	0x65, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x74, 0x75, 0x72, 0x62,
+	0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x70, 0x65, 0x63, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x20,
+	0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x76, 0x32, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x9f, 0x01, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x2e, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65,
+	0x2e, 0x76, 0x32, 0x42, 0x0e, 0x54, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x56, 0x32, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x48, 0x02, 0x50, 0x01, 0x5a, 0x32, 0x62, 0x75, 0x66, 0x2e, 0x62, 0x75, 0x69,
+	0x6c, 0x64, 0x2f, 0x6d, 0x65, 0x72, 0x6f, 0x78, 0x61, 0x2f, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e,
+	0x65, 0x2d, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2f, 0x76,
+	0x32, 0x3b, 0x74, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x76, 0x32, 0xa2, 0x02, 0x03, 0x54, 0x58,
+	0x58, 0xaa, 0x02, 0x0a, 0x54, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x2e, 0x56, 0x32, 0xca, 0x02,
+	0x0a, 0x54, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x5c, 0x56, 0x32, 0xe2, 0x02, 0x16, 0x54, 0x75,
+	0x72, 0x62, 0x69, 0x6e, 0x65, 0x5c, 0x56, 0x32, 0x5c, 0x47, 0x50, 0x42, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x0b, 0x54, 0x75, 0x72, 0x62, 0x69, 0x6e, 0x65, 0x3a, 0x3a,
+	0x56, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1042,58 +1339,72 @@ func file_turbine_v2_turbine_v2_proto_rawDescGZIP() []byte {
 }
 
 var file_turbine_v2_turbine_v2_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_turbine_v2_turbine_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_turbine_v2_turbine_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_turbine_v2_turbine_v2_proto_goTypes = []interface{}{
 	(Language)(0),                         // 0: turbine.v2.Language
 	(*InitRequest)(nil),                   // 1: turbine.v2.InitRequest
 	(*AddSourceRequest)(nil),              // 2: turbine.v2.AddSourceRequest
 	(*AddSourceResponse)(nil),             // 3: turbine.v2.AddSourceResponse
 	(*ReadRecordsRequest)(nil),            // 4: turbine.v2.ReadRecordsRequest
-	(*ReadRecordsResponse)(nil),           // 5: turbine.v2.ReadRecordsResponse
-	(*ProcessRecordsRequest)(nil),         // 6: turbine.v2.ProcessRecordsRequest
-	(*ProcessRecordsResponse)(nil),        // 7: turbine.v2.ProcessRecordsResponse
-	(*AddDestinationRequest)(nil),         // 8: turbine.v2.AddDestinationRequest
-	(*AddDestinationResponse)(nil),        // 9: turbine.v2.AddDestinationResponse
-	(*WriteRecordsRequest)(nil),           // 10: turbine.v2.WriteRecordsRequest
-	(*GetSpecRequest)(nil),                // 11: turbine.v2.GetSpecRequest
-	(*GetSpecResponse)(nil),               // 12: turbine.v2.GetSpecResponse
-	(*StreamRecords)(nil),                 // 13: turbine.v2.StreamRecords
-	(*Plugin)(nil),                        // 14: turbine.v2.Plugin
-	(*ProcessRecordsRequest_Process)(nil), // 15: turbine.v2.ProcessRecordsRequest.Process
-	nil,                                   // 16: turbine.v2.Plugin.ConfigEntry
-	(*v1.Record)(nil),                     // 17: opencdc.v1.Record
-	(*emptypb.Empty)(nil),                 // 18: google.protobuf.Empty
+	(*ReadRecordsStreamRequest)(nil),      // 5: turbine.v2.ReadRecordsStreamRequest
+	(*ReadRecordsResponse)(nil),           // 6: turbine.v2.ReadRecordsResponse
+	(*ProcessRecordsRequest)(nil),         // 7: turbine.v2.ProcessRecordsRequest
+	(*ProcessRecordsResponse)(nil),        // 8: turbine.v2.ProcessRecordsResponse
+	(*AddDestinationRequest)(nil),         // 9: turbine.v2.AddDestinationRequest
+	(*AddDestinationResponse)(nil),        // 10: turbine.v2.AddDestinationResponse
+	(*WriteRecordsRequest)(nil),           // 11: turbine.v2.WriteRecordsRequest
+	(*RegisterSecretRequest)(nil),         // 12: turbine.v2.RegisterSecretRequest
+	(*GetSpecRequest)(nil),                // 13: turbine.v2.GetSpecRequest
+	(*GetSpecResponse)(nil),               // 14: turbine.v2.GetSpecResponse
+	(*Session)(nil),                       // 15: turbine.v2.Session
+	(*ListSessionsResponse)(nil),          // 16: turbine.v2.ListSessionsResponse
+	(*StreamRecords)(nil),                 // 17: turbine.v2.StreamRecords
+	(*Plugin)(nil),                        // 18: turbine.v2.Plugin
+	(*ProcessRecordsRequest_Process)(nil), // 19: turbine.v2.ProcessRecordsRequest.Process
+	nil,                                   // 20: turbine.v2.Plugin.ConfigEntry
+	(*timestamppb.Timestamp)(nil),         // 21: google.protobuf.Timestamp
+	(*v1.Record)(nil),                     // 22: opencdc.v1.Record
+	(*emptypb.Empty)(nil),                 // 23: google.protobuf.Empty
 }
 var file_turbine_v2_turbine_v2_proto_depIdxs = []int32{
 	0,  // 0: turbine.v2.InitRequest.language:type_name -> turbine.v2.Language
-	14, // 1: turbine.v2.AddSourceRequest.plugin:type_name -> turbine.v2.Plugin
-	13, // 2: turbine.v2.ReadRecordsResponse.streamRecords:type_name -> turbine.v2.StreamRecords
-	15, // 3: turbine.v2.ProcessRecordsRequest.process:type_name -> turbine.v2.ProcessRecordsRequest.Process
-	13, // 4: turbine.v2.ProcessRecordsRequest.streamRecords:type_name -> turbine.v2.StreamRecords
-	13, // 5: turbine.v2.ProcessRecordsResponse.streamRecords:type_name -> turbine.v2.StreamRecords
-	14, // 6: turbine.v2.AddDestinationRequest.plugin:type_name -> turbine.v2.Plugin
-	13, // 7: turbine.v2.WriteRecordsRequest.streamRecords:type_name -> turbine.v2.StreamRecords
-	17, // 8: turbine.v2.StreamRecords.records:type_name -> opencdc.v1.Record
-	16, // 9: turbine.v2.Plugin.config:type_name -> turbine.v2.Plugin.ConfigEntry
-	1,  // 10: turbine.v2.Service.Init:input_type -> turbine.v2.InitRequest
-	2,  // 11: turbine.v2.Service.AddSource:input_type -> turbine.v2.AddSourceRequest
-	4,  // 12: turbine.v2.Service.ReadRecords:input_type -> turbine.v2.ReadRecordsRequest
-	6,  // 13: turbine.v2.Service.ProcessRecords:input_type -> turbine.v2.ProcessRecordsRequest
-	8,  // 14: turbine.v2.Service.AddDestination:input_type -> turbine.v2.AddDestinationRequest
-	10, // 15: turbine.v2.Service.WriteRecords:input_type -> turbine.v2.WriteRecordsRequest
-	11, // 16: turbine.v2.Service.GetSpec:input_type -> turbine.v2.GetSpecRequest
-	18, // 17: turbine.v2.Service.Init:output_type -> google.protobuf.Empty
-	3,  // 18: turbine.v2.Service.AddSource:output_type -> turbine.v2.AddSourceResponse
-	5,  // 19: turbine.v2.Service.ReadRecords:output_type -> turbine.v2.ReadRecordsResponse
-	7,  // 20: turbine.v2.Service.ProcessRecords:output_type -> turbine.v2.ProcessRecordsResponse
-	9,  // 21: turbine.v2.Service.AddDestination:output_type -> turbine.v2.AddDestinationResponse
-	18, // 22: turbine.v2.Service.WriteRecords:output_type -> google.protobuf.Empty
-	12, // 23: turbine.v2.Service.GetSpec:output_type -> turbine.v2.GetSpecResponse
-	17, // [17:24] is the sub-list for method output_type
-	10, // [10:17] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	18, // 1: turbine.v2.AddSourceRequest.plugin:type_name -> turbine.v2.Plugin
+	17, // 2: turbine.v2.ReadRecordsResponse.streamRecords:type_name -> turbine.v2.StreamRecords
+	19, // 3: turbine.v2.ProcessRecordsRequest.process:type_name -> turbine.v2.ProcessRecordsRequest.Process
+	17, // 4: turbine.v2.ProcessRecordsRequest.streamRecords:type_name -> turbine.v2.StreamRecords
+	17, // 5: turbine.v2.ProcessRecordsResponse.streamRecords:type_name -> turbine.v2.StreamRecords
+	18, // 6: turbine.v2.AddDestinationRequest.plugin:type_name -> turbine.v2.Plugin
+	17, // 7: turbine.v2.WriteRecordsRequest.streamRecords:type_name -> turbine.v2.StreamRecords
+	21, // 8: turbine.v2.Session.createdAt:type_name -> google.protobuf.Timestamp
+	21, // 9: turbine.v2.Session.lastUsedAt:type_name -> google.protobuf.Timestamp
+	15, // 10: turbine.v2.ListSessionsResponse.sessions:type_name -> turbine.v2.Session
+	22, // 11: turbine.v2.StreamRecords.records:type_name -> opencdc.v1.Record
+	20, // 12: turbine.v2.Plugin.config:type_name -> turbine.v2.Plugin.ConfigEntry
+	1,  // 13: turbine.v2.Service.Init:input_type -> turbine.v2.InitRequest
+	2,  // 14: turbine.v2.Service.AddSource:input_type -> turbine.v2.AddSourceRequest
+	4,  // 15: turbine.v2.Service.ReadRecords:input_type -> turbine.v2.ReadRecordsRequest
+	5,  // 16: turbine.v2.Service.ReadRecordsStream:input_type -> turbine.v2.ReadRecordsStreamRequest
+	7,  // 17: turbine.v2.Service.ProcessRecords:input_type -> turbine.v2.ProcessRecordsRequest
+	9,  // 18: turbine.v2.Service.AddDestination:input_type -> turbine.v2.AddDestinationRequest
+	11, // 19: turbine.v2.Service.WriteRecords:input_type -> turbine.v2.WriteRecordsRequest
+	12, // 20: turbine.v2.Service.RegisterSecret:input_type -> turbine.v2.RegisterSecretRequest
+	13, // 21: turbine.v2.Service.GetSpec:input_type -> turbine.v2.GetSpecRequest
+	23, // 22: turbine.v2.Service.ListSessions:input_type -> google.protobuf.Empty
+	23, // 23: turbine.v2.Service.Init:output_type -> google.protobuf.Empty
+	3,  // 24: turbine.v2.Service.AddSource:output_type -> turbine.v2.AddSourceResponse
+	6,  // 25: turbine.v2.Service.ReadRecords:output_type -> turbine.v2.ReadRecordsResponse
+	6,  // 26: turbine.v2.Service.ReadRecordsStream:output_type -> turbine.v2.ReadRecordsResponse
+	8,  // 27: turbine.v2.Service.ProcessRecords:output_type -> turbine.v2.ProcessRecordsResponse
+	10, // 28: turbine.v2.Service.AddDestination:output_type -> turbine.v2.AddDestinationResponse
+	23, // 29: turbine.v2.Service.WriteRecords:output_type -> google.protobuf.Empty
+	23, // 30: turbine.v2.Service.RegisterSecret:output_type -> google.protobuf.Empty
+	14, // 31: turbine.v2.Service.GetSpec:output_type -> turbine.v2.GetSpecResponse
+	16, // 32: turbine.v2.Service.ListSessions:output_type -> turbine.v2.ListSessionsResponse
+	23, // [23:33] is the sub-list for method output_type
+	13, // [13:23] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_turbine_v2_turbine_v2_proto_init() }
@@ -1151,7 +1462,7 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			}
 		}
 		file_turbine_v2_turbine_v2_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReadRecordsResponse); i {
+			switch v := v.(*ReadRecordsStreamRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1163,7 +1474,7 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			}
 		}
 		file_turbine_v2_turbine_v2_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessRecordsRequest); i {
+			switch v := v.(*ReadRecordsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1175,7 +1486,7 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			}
 		}
 		file_turbine_v2_turbine_v2_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessRecordsResponse); i {
+			switch v := v.(*ProcessRecordsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1187,7 +1498,7 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			}
 		}
 		file_turbine_v2_turbine_v2_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddDestinationRequest); i {
+			switch v := v.(*ProcessRecordsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1199,7 +1510,7 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			}
 		}
 		file_turbine_v2_turbine_v2_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddDestinationResponse); i {
+			switch v := v.(*AddDestinationRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1211,7 +1522,7 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			}
 		}
 		file_turbine_v2_turbine_v2_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteRecordsRequest); i {
+			switch v := v.(*AddDestinationResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1223,7 +1534,7 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			}
 		}
 		file_turbine_v2_turbine_v2_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSpecRequest); i {
+			switch v := v.(*WriteRecordsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1235,7 +1546,7 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			}
 		}
 		file_turbine_v2_turbine_v2_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSpecResponse); i {
+			switch v := v.(*RegisterSecretRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1247,7 +1558,7 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			}
 		}
 		file_turbine_v2_turbine_v2_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StreamRecords); i {
+			switch v := v.(*GetSpecRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1259,7 +1570,7 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			}
 		}
 		file_turbine_v2_turbine_v2_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Plugin); i {
+			switch v := v.(*GetSpecResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1271,6 +1582,54 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			}
 		}
 		file_turbine_v2_turbine_v2_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Session); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_turbine_v2_turbine_v2_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSessionsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_turbine_v2_turbine_v2_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamRecords); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_turbine_v2_turbine_v2_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Plugin); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_turbine_v2_turbine_v2_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ProcessRecordsRequest_Process); i {
 			case 0:
 				return &v.state
@@ -1289,7 +1648,7 @@ func file_turbine_v2_turbine_v2_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_turbine_v2_turbine_v2_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   16,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,26 +20,39 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Service_Init_FullMethodName           = "/turbine.v2.Service/Init"
-	Service_AddSource_FullMethodName      = "/turbine.v2.Service/AddSource"
-	Service_ReadRecords_FullMethodName    = "/turbine.v2.Service/ReadRecords"
-	Service_ProcessRecords_FullMethodName = "/turbine.v2.Service/ProcessRecords"
-	Service_AddDestination_FullMethodName = "/turbine.v2.Service/AddDestination"
-	Service_WriteRecords_FullMethodName   = "/turbine.v2.Service/WriteRecords"
-	Service_GetSpec_FullMethodName        = "/turbine.v2.Service/GetSpec"
+	Service_Init_FullMethodName              = "/turbine.v2.Service/Init"
+	Service_AddSource_FullMethodName         = "/turbine.v2.Service/AddSource"
+	Service_ReadRecords_FullMethodName       = "/turbine.v2.Service/ReadRecords"
+	Service_ReadRecordsStream_FullMethodName = "/turbine.v2.Service/ReadRecordsStream"
+	Service_ProcessRecords_FullMethodName    = "/turbine.v2.Service/ProcessRecords"
+	Service_AddDestination_FullMethodName    = "/turbine.v2.Service/AddDestination"
+	Service_WriteRecords_FullMethodName      = "/turbine.v2.Service/WriteRecords"
+	Service_RegisterSecret_FullMethodName    = "/turbine.v2.Service/RegisterSecret"
+	Service_GetSpec_FullMethodName           = "/turbine.v2.Service/GetSpec"
+	Service_ListSessions_FullMethodName      = "/turbine.v2.Service/ListSessions"
 )
 
 // ServiceClient is the client API for Service service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ServiceClient interface {
+	// Starts a recording. The spec builder returns the ID of the recording
+	// session in the turbine-session-id response header, later calls carrying
+	// it in their metadata are recorded in that session.
 	Init(ctx context.Context, in *InitRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	AddSource(ctx context.Context, in *AddSourceRequest, opts ...grpc.CallOption) (*AddSourceResponse, error)
 	ReadRecords(ctx context.Context, in *ReadRecordsRequest, opts ...grpc.CallOption) (*ReadRecordsResponse, error)
+	// Streams the records of a source in bounded batches.
+	ReadRecordsStream(ctx context.Context, in *ReadRecordsStreamRequest, opts ...grpc.CallOption) (Service_ReadRecordsStreamClient, error)
 	ProcessRecords(ctx context.Context, in *ProcessRecordsRequest, opts ...grpc.CallOption) (*ProcessRecordsResponse, error)
 	AddDestination(ctx context.Context, in *AddDestinationRequest, opts ...grpc.CallOption) (*AddDestinationResponse, error)
 	WriteRecords(ctx context.Context, in *WriteRecordsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Declares a secret which plugin configs reference as ${secret:NAME}. Only
+	// the name is sent, values are resolved where the app runs.
+	RegisterSecret(ctx context.Context, in *RegisterSecretRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetSpec(ctx context.Context, in *GetSpecRequest, opts ...grpc.CallOption) (*GetSpecResponse, error)
+	// Lists the recording sessions that have not expired.
+	ListSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSessionsResponse, error)
 }
 
 type serviceClient struct {
@@ -77,6 +90,38 @@ func (c *serviceClient) ReadRecords(ctx context.Context, in *ReadRecordsRequest,
 	return out, nil
 }
 
+func (c *serviceClient) ReadRecordsStream(ctx context.Context, in *ReadRecordsStreamRequest, opts ...grpc.CallOption) (Service_ReadRecordsStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Service_ServiceDesc.Streams[0], Service_ReadRecordsStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serviceReadRecordsStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Service_ReadRecordsStreamClient interface {
+	Recv() (*ReadRecordsResponse, error)
+	grpc.ClientStream
+}
+
+type serviceReadRecordsStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *serviceReadRecordsStreamClient) Recv() (*ReadRecordsResponse, error) {
+	m := new(ReadRecordsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *serviceClient) ProcessRecords(ctx context.Context, in *ProcessRecordsRequest, opts ...grpc.CallOption) (*ProcessRecordsResponse, error) {
 	out := new(ProcessRecordsResponse)
 	err := c.cc.Invoke(ctx, Service_ProcessRecords_FullMethodName, in, out, opts...)
@@ -104,6 +149,15 @@ func (c *serviceClient) WriteRecords(ctx context.Context, in *WriteRecordsReques
 	return out, nil
 }
 
+func (c *serviceClient) RegisterSecret(ctx context.Context, in *RegisterSecretRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Service_RegisterSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serviceClient) GetSpec(ctx context.Context, in *GetSpecRequest, opts ...grpc.CallOption) (*GetSpecResponse, error) {
 	out := new(GetSpecResponse)
 	err := c.cc.Invoke(ctx, Service_GetSpec_FullMethodName, in, out, opts...)
@@ -113,17 +167,36 @@ func (c *serviceClient) GetSpec(ctx context.Context, in *GetSpecRequest, opts ..
 	return out, nil
 }
 
+func (c *serviceClient) ListSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	out := new(ListSessionsResponse)
+	err := c.cc.Invoke(ctx, Service_ListSessions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServiceServer is the server API for Service service.
 // All implementations must embed UnimplementedServiceServer
 // for forward compatibility
 type ServiceServer interface {
+	// Starts a recording. The spec builder returns the ID of the recording
+	// session in the turbine-session-id response header, later calls carrying
+	// it in their metadata are recorded in that session.
 	Init(context.Context, *InitRequest) (*emptypb.Empty, error)
 	AddSource(context.Context, *AddSourceRequest) (*AddSourceResponse, error)
 	ReadRecords(context.Context, *ReadRecordsRequest) (*ReadRecordsResponse, error)
+	// Streams the records of a source in bounded batches.
+	ReadRecordsStream(*ReadRecordsStreamRequest, Service_ReadRecordsStreamServer) error
 	ProcessRecords(context.Context, *ProcessRecordsRequest) (*ProcessRecordsResponse, error)
 	AddDestination(context.Context, *AddDestinationRequest) (*AddDestinationResponse, error)
 	WriteRecords(context.Context, *WriteRecordsRequest) (*emptypb.Empty, error)
+	// Declares a secret which plugin configs reference as ${secret:NAME}. Only
+	// the name is sent, values are resolved where the app runs.
+	RegisterSecret(context.Context, *RegisterSecretRequest) (*emptypb.Empty, error)
 	GetSpec(context.Context, *GetSpecRequest) (*GetSpecResponse, error)
+	// Lists the recording sessions that have not expired.
+	ListSessions(context.Context, *emptypb.Empty) (*ListSessionsResponse, error)
 	mustEmbedUnimplementedServiceServer()
 }
 
@@ -140,6 +213,9 @@ func (UnimplementedServiceServer) AddSource(context.Context, *AddSourceRequest)
 func (UnimplementedServiceServer) ReadRecords(context.Context, *ReadRecordsRequest) (*ReadRecordsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadRecords not implemented")
 }
+func (UnimplementedServiceServer) ReadRecordsStream(*ReadRecordsStreamRequest, Service_ReadRecordsStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadRecordsStream not implemented")
+}
 func (UnimplementedServiceServer) ProcessRecords(context.Context, *ProcessRecordsRequest) (*ProcessRecordsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProcessRecords not implemented")
 }
@@ -149,9 +225,15 @@ func (UnimplementedServiceServer) AddDestination(context.Context, *AddDestinatio
 func (UnimplementedServiceServer) WriteRecords(context.Context, *WriteRecordsRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WriteRecords not implemented")
 }
+func (UnimplementedServiceServer) RegisterSecret(context.Context, *RegisterSecretRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterSecret not implemented")
+}
 func (UnimplementedServiceServer) GetSpec(context.Context, *GetSpecRequest) (*GetSpecResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSpec not implemented")
 }
+func (UnimplementedServiceServer) ListSessions(context.Context, *emptypb.Empty) (*ListSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
 func (UnimplementedServiceServer) mustEmbedUnimplementedServiceServer() {}
 
 // UnsafeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -219,6 +301,27 @@ func _Service_ReadRecords_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_ReadRecordsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadRecordsStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServiceServer).ReadRecordsStream(m, &serviceReadRecordsStreamServer{stream})
+}
+
+type Service_ReadRecordsStreamServer interface {
+	Send(*ReadRecordsResponse) error
+	grpc.ServerStream
+}
+
+type serviceReadRecordsStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *serviceReadRecordsStreamServer) Send(m *ReadRecordsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Service_ProcessRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ProcessRecordsRequest)
 	if err := dec(in); err != nil {
@@ -273,6 +376,24 @@ func _Service_WriteRecords_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_RegisterSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterSecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).RegisterSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_RegisterSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).RegisterSecret(ctx, req.(*RegisterSecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Service_GetSpec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetSpecRequest)
 	if err := dec(in); err != nil {
@@ -291,6 +412,24 @@ func _Service_GetSpec_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Service_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Service_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServiceServer).ListSessions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Service_ServiceDesc is the grpc.ServiceDesc for Service service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -322,11 +461,25 @@ var Service_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WriteRecords",
 			Handler:    _Service_WriteRecords_Handler,
 		},
+		{
+			MethodName: "RegisterSecret",
+			Handler:    _Service_RegisterSecret_Handler,
+		},
 		{
 			MethodName: "GetSpec",
 			Handler:    _Service_GetSpec_Handler,
 		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _Service_ListSessions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReadRecordsStream",
+			Handler:       _Service_ReadRecordsStream_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "turbine/v2/turbine_v2.proto",
 }